@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/achievements"
+	"bonk/internal/db"
+)
+
+func runAchievements(cmd *cobra.Command, args []string) {
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	clock := dayClock(cfg)
+	// Catch up on anything earned outside a drill, e.g. by offline recall.
+	if _, err := database.UnlockAchievements(clock, ""); err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking achievements: %v\n", err)
+		os.Exit(1)
+	}
+	facts, err := database.GetAchievementFacts(clock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+		os.Exit(1)
+	}
+	unlocked, err := database.GetUnlockedAchievements()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading achievements: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\nAchievements: %d/%d unlocked\n", len(unlocked), len(achievements.All))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, a := range achievements.All {
+		if at, ok := unlocked[a.ID]; ok {
+			fmt.Printf("★ %-32s %-12s %s\n", a.Name, at[:min(10, len(at))], a.Description)
+			continue
+		}
+		fmt.Printf("  %-32s %-12s %s\n", a.Name, progressBar(a.Progress(facts), a.Goal), a.Description)
+	}
+	fmt.Println()
+}
+
+// progressBar renders progress toward a goal as a short bar and a count.
+func progressBar(n, goal int) string {
+	const width = 5
+	filled := n * width / goal
+	return strings.Repeat("■", filled) + strings.Repeat("□", width-filled) + fmt.Sprintf(" %d/%d", n, goal)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/db"
+	"bonk/internal/skills"
+)
+
+// calibration counts how a group of self ratings compares to the coach's.
+type calibration struct {
+	sessions int
+	over     int // self rating above the coach's
+	under    int
+	gap      int // sum of self - coach
+}
+
+func (c *calibration) add(p db.RatingPair) {
+	c.sessions++
+	c.gap += p.Self - p.Coach
+	switch {
+	case p.Self > p.Coach:
+		c.over++
+	case p.Self < p.Coach:
+		c.under++
+	}
+}
+
+func addCalibration(m map[string]*calibration, key string, p db.RatingPair) {
+	if m[key] == nil {
+		m[key] = &calibration{}
+	}
+	m[key].add(p)
+}
+
+func runCalibration(cmd *cobra.Command, args []string) {
+	days, _ := cmd.Flags().GetInt("days")
+	if days < 1 {
+		fmt.Fprintf(os.Stderr, "--days must be at least 1\n")
+		os.Exit(1)
+	}
+
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	c := dayClock(cfg)
+	since := c.StartOfDay(time.Now()).AddDate(0, 0, -(days - 1))
+	pairs, err := database.GetRatingPairs(since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading ratings: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	if len(pairs) == 0 {
+		fmt.Printf("No sessions rated by both you and the coach in the last %d days.\n", days)
+		return
+	}
+
+	var total calibration
+	byDomain := make(map[string]*calibration)
+	byWeek := make(map[string]*calibration)
+	var weeks []string
+	for _, p := range pairs {
+		domain := "unknown"
+		if s := skills.Get(p.SkillID); s != nil {
+			domain = s.Domain
+		}
+		year, week := c.StartOfDay(p.FinishedAt).ISOWeek()
+		key := fmt.Sprintf("%d-W%02d", year, week)
+		if byWeek[key] == nil {
+			weeks = append(weeks, key)
+		}
+		total.add(p)
+		addCalibration(byDomain, domain, p)
+		addCalibration(byWeek, key, p)
+	}
+
+	fmt.Printf("Your rating vs the coach's, last %d days (%d sessions)\n", days, total.sessions)
+	fmt.Println("gap > 0: you rate yourself higher than the coach does")
+	fmt.Println()
+	printCalibrationHeader()
+	printCalibrationLine("all", &total)
+
+	fmt.Println("\nBy domain:")
+	printCalibrationHeader()
+	domains := make([]string, 0, len(byDomain))
+	for d := range byDomain {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+	for _, d := range domains {
+		printCalibrationLine(d, byDomain[d])
+	}
+
+	fmt.Println("\nBy week:")
+	printCalibrationHeader()
+	for _, w := range weeks {
+		printCalibrationLine(w, byWeek[w])
+	}
+}
+
+func printCalibrationHeader() {
+	fmt.Printf("  %-26s %8s %6s %6s %6s %6s\n", "", "sessions", "over", "match", "under", "gap")
+}
+
+func printCalibrationLine(label string, c *calibration) {
+	n := float64(c.sessions)
+	match := c.sessions - c.over - c.under
+	fmt.Printf("  %-26s %8d %5.0f%% %5.0f%% %5.0f%% %+6.2f\n", label, c.sessions,
+		float64(c.over)/n*100, float64(match)/n*100, float64(c.under)/n*100, float64(c.gap)/n)
+}
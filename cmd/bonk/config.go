@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/config"
+	"bonk/internal/llm"
+	"bonk/internal/streak"
+	"bonk/internal/voice"
+)
+
+// cfg is loaded before every command runs.
+var cfg *config.Config
+
+// flagKeys map command-line flags onto config keys. Flags are the top layer:
+// defaults < config file < environment < flags.
+var flagKeys = []struct {
+	flag, key string
+}{
+	{"db", "db.path"},
+	{"whisper-model", "voice.whisper_model"},
+	{"whisper-model-path", "voice.whisper_model_path"},
+	{"language", "voice.language"},
+	{"tts-voice", "voice.tts_voice"},
+	{"speech-rate", "voice.speech_rate"},
+	{"hands-free", "voice.hands_free"},
+	{"vad-silence", "voice.vad_silence"},
+}
+
+func addVoiceFlags(cmd *cobra.Command) {
+	for _, f := range flagKeys[1:] {
+		k, _ := config.LookupKey(f.key)
+		cmd.Flags().String(f.flag, "", k.Usage+" (config: "+f.key+")")
+		if k.Bool {
+			// --hands-free alone turns it on
+			cmd.Flags().Lookup(f.flag).NoOptDefVal = "true"
+		}
+	}
+}
+
+// loadConfig reads the config file, applies any flags set on cmd and
+// configures the API client.
+func loadConfig(cmd *cobra.Command, args []string) {
+	var err error
+	cfg, err = config.Load(config.DefaultPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	for _, f := range flagKeys {
+		if fl := cmd.Flags().Lookup(f.flag); fl != nil && fl.Changed {
+			if err := cfg.Set(f.key, fl.Value.String()); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --%s: %v\n", f.flag, err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	s := llm.Settings{
+		APIKey:            cfg.String("llm.api_key"),
+		MaxTokens:         cfg.Int("llm.max_tokens"),
+		FeedbackMaxTokens: cfg.Int("llm.feedback_max_tokens"),
+		Timeout:           time.Duration(cfg.Int("llm.timeout_seconds")) * time.Second,
+		Cassette:          llm.CassetteMode(cfg.String("llm.cassette")),
+		CassetteFile:      cfg.String("llm.cassette_file"),
+		PromptDir:         filepath.Join(config.Home(), "prompts"),
+	}
+	if s.CassetteFile == "" {
+		s.CassetteFile = filepath.Join(config.Home(), "cassette.json")
+	}
+	// Leave the built-in default alone so a model embedded at build time wins over it.
+	if cfg.Source("llm.model") != config.SourceDefault {
+		s.Model = cfg.String("llm.model")
+	}
+	if err := llm.Configure(s); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func voiceSettings(cfg *config.Config) voice.Settings {
+	s := voice.Settings{
+		DataDir:      config.Home(),
+		WhisperModel: cfg.String("voice.whisper_model"),
+		ModelPath:    cfg.String("voice.whisper_model_path"),
+		Language:     cfg.String("voice.language"),
+		Voice:        cfg.String("voice.tts_voice"),
+		Rate:         cfg.Int("voice.speech_rate"),
+	}
+	if s.ModelPath == "" {
+		s.ModelPath = voice.DefaultModelPath(s.DataDir, s.WhisperModel)
+	}
+	return s
+}
+
+// dayClock returns how practice days are counted. The time zone was
+// checked when the config loaded.
+func dayClock(cfg *config.Config) streak.Clock {
+	c := streak.Clock{RolloverHour: cfg.Int("day.rollover_hour")}
+	if tz := cfg.String("day.timezone"); tz != "" {
+		c.Loc, _ = time.LoadLocation(tz)
+	}
+	return c
+}
+
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or change settings",
+		Long: `Settings are layered: built-in defaults < config file < environment < flags.
+
+The config file is ~/.bonk/config.toml, or $BONK_HOME/config.toml when
+BONK_HOME is set. Use BONK_HOME or --db to keep separate practice profiles.`,
+	}
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List all settings with their values and where they came from",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Printf("# %s\n", cfg.Path())
+			for _, k := range config.Keys {
+				v := cfg.String(k.Name)
+				if k.Secret && v != "" {
+					v = maskSecret(v)
+				}
+				fmt.Printf("%-28s %-30s (%s)\n", k.Name, strconv.Quote(v), cfg.Source(k.Name))
+			}
+		},
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "get <key>",
+		Short: "Print a setting's effective value",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if _, ok := config.LookupKey(args[0]); !ok {
+				fmt.Fprintf(os.Stderr, "Unknown setting: %s\nUse 'bonk config list' to see settings\n", args[0])
+				os.Exit(1)
+			}
+			fmt.Println(cfg.String(args[0]))
+		},
+	})
+
+	configCmd.AddCommand(&cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Write a setting to the config file (an empty value removes it)",
+		Args:  cobra.ExactArgs(2),
+		// Don't load the config first, so set can repair a broken file.
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		Run: func(cmd *cobra.Command, args []string) {
+			path := config.DefaultPath()
+			if err := config.SetInFile(path, args[0], args[1]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if k, _ := config.LookupKey(args[0]); k.Env != "" && os.Getenv(k.Env) != "" {
+				fmt.Printf("Note: $%s is set and overrides the config file\n", k.Env)
+			}
+			fmt.Printf("Updated %s\n", path)
+		},
+	})
+
+	return configCmd
+}
+
+func maskSecret(s string) string {
+	if len(s) <= 8 {
+		return "****"
+	}
+	return s[:4] + "…" + s[len(s)-4:]
+}
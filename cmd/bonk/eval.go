@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/eval"
+	"bonk/internal/skills"
+)
+
+func newEvalCmd() *cobra.Command {
+	evalCmd := &cobra.Command{
+		Use:   "eval [skill...]",
+		Short: "Score the coach against simulated candidates",
+		Long: `Run drills against simulated candidates and score how the coach behaves:
+metadata compliance, turns to the final assessment, whether the final rating
+tracks the candidate's strength, facet coverage and, for system design
+practical interviews, answers given away by the interviewer.
+
+Candidates are either scripted answers from a fixture file (--fixture) or a
+second model playing a candidate of each --strength (1-4). Nothing is saved
+to the practice database and eval calls don't count toward token budgets;
+run with BONK_LLM_CASSETTE=record to replay a run offline later.
+
+Fixture format:
+  {"candidates": [{"name": "weak", "skill": "hash-maps", "strength": 1,
+                   "answers": ["first answer", "second answer"]}]}
+
+Examples:
+  bonk eval hash-maps tries --runs 3
+  bonk eval --domain sysp --strength 1,3 --out sysp.md
+  bonk eval --domain ds --fixture candidates.json --format json`,
+		Run: runEval,
+	}
+	evalCmd.Flags().String("domain", "", "Evaluate every skill in a domain (ds, algo, sys, sysp, lc, est)")
+	evalCmd.Flags().Int("runs", 1, "Drills per skill and candidate")
+	evalCmd.Flags().String("fixture", "", "JSON file of scripted candidates")
+	evalCmd.Flags().IntSlice("strength", []int{1, 4}, "Strengths of simulated candidates (ignored with --fixture)")
+	evalCmd.Flags().Int64("seed", 1, "Seed for estimation scenarios, so reports compare the same drills")
+	evalCmd.Flags().String("format", "md", "Report format: md or json")
+	evalCmd.Flags().StringP("out", "o", "", "Write the report to a file instead of stdout")
+	return evalCmd
+}
+
+func runEval(cmd *cobra.Command, args []string) {
+	domainArg, _ := cmd.Flags().GetString("domain")
+	runs, _ := cmd.Flags().GetInt("runs")
+	fixturePath, _ := cmd.Flags().GetString("fixture")
+	strengths, _ := cmd.Flags().GetIntSlice("strength")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	format, _ := cmd.Flags().GetString("format")
+	out, _ := cmd.Flags().GetString("out")
+
+	if format != "md" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Unknown format: %s (use md or json)\n", format)
+		os.Exit(1)
+	}
+
+	var selected []*skills.Skill
+	for _, id := range args {
+		s := skills.Get(id)
+		if s == nil {
+			fmt.Fprintf(os.Stderr, "Unknown skill: %s\n", id)
+			os.Exit(1)
+		}
+		selected = append(selected, s)
+	}
+	if domainArg != "" {
+		domain, ok := skills.DomainMap[domainArg]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown domain: %s\nAvailable: ds, algo, sys, sysp, lc, est\n", domainArg)
+			os.Exit(1)
+		}
+		selected = append(selected, skills.ListByDomain(domain)...)
+	}
+	if len(selected) == 0 {
+		fmt.Fprintf(os.Stderr, "Name skills to evaluate or pass --domain\n")
+		os.Exit(1)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].ID < selected[j].ID })
+
+	var candidates func(*skills.Skill) []eval.Candidate
+	if fixturePath != "" {
+		fixture, err := eval.LoadFixture(fixturePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		candidates = func(s *skills.Skill) []eval.Candidate { return fixture.For(s.ID) }
+	} else {
+		var simulated []eval.Candidate
+		for _, n := range strengths {
+			if n < 1 || n > 4 {
+				fmt.Fprintf(os.Stderr, "--strength must be between 1 and 4\n")
+				os.Exit(1)
+			}
+			simulated = append(simulated, eval.Simulated{Level: n})
+		}
+		candidates = func(*skills.Skill) []eval.Candidate { return simulated }
+	}
+
+	report, err := eval.Run(cmd.Context(), eval.Options{
+		Skills:            selected,
+		Candidates:        candidates,
+		Runs:              runs,
+		MaxTurns:          cfg.Int("drill.max_turns"),
+		PracticalMaxTurns: cfg.Int("drill.practical_max_turns"),
+		Seed:              seed,
+		Progress: func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, format, args...)
+		},
+	})
+	if err != nil {
+		// Cancelled: still report what finished.
+		fmt.Fprintf(os.Stderr, "Eval stopped: %v\n", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", out, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "json" {
+		err = report.WriteJSON(w)
+	} else {
+		err = report.WriteMarkdown(w)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+		os.Exit(1)
+	}
+	if out != "" {
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", out)
+	}
+}
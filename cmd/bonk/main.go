@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,17 +16,32 @@ import (
 	"bonk/internal/buildinfo"
 	"bonk/internal/db"
 	"bonk/internal/llm"
+	"bonk/internal/notebook"
+	"bonk/internal/pacing"
 	"bonk/internal/serve"
 	"bonk/internal/skills"
+	"bonk/internal/transcript"
 	"bonk/internal/tui"
+	"bonk/internal/voice"
 )
 
 // selectSkill picks the next skill to drill using SM-2 priority:
-// 1. Due skills (overdue based on scheduling)
-// 2. New skills (never reviewed)
-// 3. Random (fallback)
+// 1. Today's plan (skills planned for today or earlier, not yet drilled)
+// 2. Due skills (overdue based on scheduling)
+// 3. New skills (never reviewed)
+// 4. Random (fallback)
 func selectSkill(database *db.DB, domainFilter string) *skills.Skill {
-	// Check for due skills first
+	// While a study plan is active, follow it
+	planned, _ := database.GetPendingPlanSkills(dayClock(cfg).Day(time.Now()))
+	for _, id := range planned {
+		if s := skills.Get(id); s != nil {
+			if domainFilter == "" || s.Domain == domainFilter {
+				return s
+			}
+		}
+	}
+
+	// Then skills due for review
 	dueSkills, _ := database.GetDueSkills()
 	for _, due := range dueSkills {
 		if s := skills.Get(due.SkillID); s != nil {
@@ -75,14 +90,19 @@ Domains:
   algo  - Algorithm Patterns (sliding window, binary search, etc.)
   sys   - System Design (load balancing, caching, etc.)
   sysp  - System Design Practical (interview simulations)
-  lc    - LeetCode Patterns (problem-solving archetypes)`,
-		Args: cobra.MaximumNArgs(1),
-		Run:  runDrill,
+  lc    - LeetCode Patterns (problem-solving archetypes)
+  est   - Estimation (back-of-the-envelope QPS, storage, bandwidth)`,
+		Args:             cobra.MaximumNArgs(1),
+		PersistentPreRun: loadConfig,
+		Run:              runDrill,
 	}
 	rootCmd.Version = buildinfo.Version
+	rootCmd.PersistentFlags().String("db", "", "SQLite database file (config: db.path)")
 
 	rootCmd.Flags().String("skill", "", "Specific skill ID to drill")
 	rootCmd.Flags().BoolP("voice", "v", true, "Voice mode (TTS for coach, space to record). Use --voice=false to disable")
+	rootCmd.Flags().Bool("offline", false, "Self-graded recall cards from local material; no API key needed")
+	addVoiceFlags(rootCmd)
 
 	// List command
 	listCmd := &cobra.Command{
@@ -109,7 +129,7 @@ Domains:
 		Long: `Show detailed information about a skill including its facets and example problems.
 
 Examples:
-  bonk info hash-maps     Show details for hash-maps skill
+  bonk info hash-maps     Show details for hash-maps skill, with your notes
   bonk info --all         List all skills with full details`,
 		Args: cobra.MaximumNArgs(1),
 		Run:  runInfo,
@@ -129,15 +149,19 @@ Examples:
 	// Setup command for voice mode dependencies
 	setupCmd := &cobra.Command{
 		Use:   "setup",
-		Short: "Set up voice mode dependencies (macOS)",
+		Short: "Set up voice mode dependencies (macOS/Linux)",
 		Long: `Install dependencies for voice mode:
   - sox (audio recording)
   - whisper-cpp (speech-to-text)
+  - espeak-ng (text-to-speech, Linux only; macOS uses 'say')
   - whisper model file
 
-Requires Homebrew on macOS.`,
+Uses Homebrew on macOS. On Linux, uses apt, dnf, pacman, zypper or Homebrew.
+The model comes from --whisper-model or voice.whisper_model in
+~/.bonk/config.toml and is verified against its published checksum.`,
 		Run: runSetup,
 	}
+	addVoiceFlags(setupCmd)
 	rootCmd.AddCommand(setupCmd)
 
 	// Review command - view and get feedback on past sessions
@@ -150,20 +174,72 @@ on your performance, communication style, and areas to improve.
 Examples:
   bonk review              Review your most recent session
   bonk review hash-maps    Review your last hash-maps session
-  bonk review --feedback   Get AI feedback on your last session`,
+  bonk review --feedback   Get AI feedback on your last session
+  bonk review --doc > design.md
+                           Export the design notebook of your last system
+                           design interview as markdown
+  bonk review --out session.html
+                           Export the whole session as a standalone web page
+  bonk review --format json -f
+                           Print the session and AI feedback as JSON`,
 		Args: cobra.MaximumNArgs(1),
 		Run:  runReview,
 	}
 	reviewCmd.Flags().BoolP("feedback", "f", false, "Get AI feedback on the session")
+	reviewCmd.Flags().Bool("doc", false, "Print the session's design notebook as a markdown design doc")
+	reviewCmd.Flags().String("format", "", "Export the session as md, html or json (default: from --out, else md)")
+	reviewCmd.Flags().StringP("out", "o", "", "Write the export to a file instead of stdout")
 	rootCmd.AddCommand(reviewCmd)
 
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newPromptsCmd())
+	rootCmd.AddCommand(newEvalCmd())
+	rootCmd.AddCommand(newNotesCmd())
+	rootCmd.AddCommand(newDueCmd())
+	rootCmd.AddCommand(newRemindCmd())
+	rootCmd.AddCommand(newPlanCmd())
+
+	// Usage command - token and cost accounting
+	usageCmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show API token usage and estimated cost",
+		Long: `Show API token usage by day, domain and skill with estimated cost at
+list prices, plus progress against any token budgets
+(budget.daily_tokens, budget.monthly_tokens).`,
+		Args: cobra.NoArgs,
+		Run:  runUsage,
+	}
+	usageCmd.Flags().Int("days", 30, "Number of days to include")
+	rootCmd.AddCommand(usageCmd)
+
+	// Calibration command - self rating vs coach rating
+	calibrationCmd := &cobra.Command{
+		Use:   "calibration",
+		Short: "Compare your self ratings with the coach's",
+		Long: `Show how often you rate yourself above or below the coach, per domain
+and per week. Which rating schedules reviews is set by rating.blend
+(coach, self or weighted) and rating.self_weight.`,
+		Args: cobra.NoArgs,
+		Run:  runCalibration,
+	}
+	calibrationCmd.Flags().Int("days", 90, "Number of days to include")
+	rootCmd.AddCommand(calibrationCmd)
+
+	achievementsCmd := &cobra.Command{
+		Use:   "achievements",
+		Short: "List achievements and your progress toward them",
+		Args:  cobra.NoArgs,
+		Run:   runAchievements,
+	}
+	rootCmd.AddCommand(achievementsCmd)
+
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
 	}
 }
 
 func runDrill(cmd *cobra.Command, args []string) {
-	database, err := db.Open()
+	database, err := db.Open(cfg.DBPath())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
 		os.Exit(1)
@@ -185,7 +261,7 @@ func runDrill(cmd *cobra.Command, args []string) {
 		// Domain filter specified
 		domain, ok := skills.DomainMap[args[0]]
 		if !ok {
-			fmt.Fprintf(os.Stderr, "Unknown domain: %s\nAvailable: ds, algo, sys, sysp, lc\n", args[0])
+			fmt.Fprintf(os.Stderr, "Unknown domain: %s\nAvailable: ds, algo, sys, sysp, lc, est\n", args[0])
 			os.Exit(1)
 		}
 		domainFilter = domain
@@ -200,11 +276,44 @@ func runDrill(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	offline, _ := cmd.Flags().GetBool("offline")
+	if !offline && !llm.Available() {
+		fmt.Fprintf(os.Stderr, "No API key set, starting offline recall mode.\n"+
+			"Export ANTHROPIC_API_KEY or run: bonk config set llm.api_key <key> for coached drills.\n")
+		offline = true
+	}
+	if offline {
+		runRecall(database, skill, domainFilter)
+		return
+	}
+
 	// Run drill loop
 	allowDomainPicker := skillFlag == "" && len(args) == 0
-	voiceEnabled, _ := cmd.Flags().GetBool("voice")
+	opts := tui.Options{
+		MaxTurns:          cfg.Int("drill.max_turns"),
+		PracticalMaxTurns: cfg.Int("drill.practical_max_turns"),
+		RatingBlend: db.RatingBlend{
+			Mode:       cfg.String("rating.blend"),
+			SelfWeight: cfg.Int("rating.self_weight"),
+		},
+		Clock:      dayClock(cfg),
+		WeeklyGoal: cfg.Int("goal.weekly_sessions"),
+	}
+	if voiceEnabled, _ := cmd.Flags().GetBool("voice"); voiceEnabled {
+		opts.Voice = voice.Detect(voiceSettings(cfg))
+		if cfg.Bool("voice.hands_free") {
+			vad := voice.DefaultVADConfig()
+			vad.Silence = time.Duration(cfg.Int("voice.vad_silence")) * time.Millisecond
+			opts.HandsFree = &vad
+		}
+	}
 	for {
-		m := tui.NewModel(database, skill, allowDomainPicker, voiceEnabled)
+		if err := checkBudget(database, dayClock(cfg), time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		opts.AllowDomainPicker = allowDomainPicker
+		m := tui.NewModel(database, skill, opts)
 		p := tea.NewProgram(m, tea.WithAltScreen())
 
 		finalModel, err := p.Run()
@@ -233,12 +342,30 @@ func runDrill(cmd *cobra.Command, args []string) {
 	}
 }
 
+// runRecall runs offline recall sessions until the user stops, picking
+// the next skill like a drill would.
+func runRecall(database *db.DB, skill *skills.Skill, domainFilter string) {
+	for skill != nil {
+		p := tea.NewProgram(tui.NewRecallModel(database, skill, dayClock(cfg)), tea.WithAltScreen())
+		finalModel, err := p.Run()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fm, ok := finalModel.(tui.RecallModel)
+		if !ok || !fm.ShouldContinue() {
+			return
+		}
+		skill = selectSkill(database, domainFilter)
+	}
+}
+
 func runList(cmd *cobra.Command, args []string) {
 	var domainFilter string
 	if len(args) > 0 {
 		domain, ok := skills.DomainMap[args[0]]
 		if !ok {
-			fmt.Fprintf(os.Stderr, "Unknown domain: %s\nAvailable: ds, algo, sys, sysp, lc\n", args[0])
+			fmt.Fprintf(os.Stderr, "Unknown domain: %s\nAvailable: ds, algo, sys, sysp, lc, est\n", args[0])
 			os.Exit(1)
 		}
 		domainFilter = domain
@@ -319,6 +446,19 @@ func runInfo(cmd *cobra.Command, args []string) {
 
 	fmt.Println()
 	printSkillInfo(skill)
+
+	// Notes are a bonus here; skip them if the database can't be read.
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		return
+	}
+	defer database.Close()
+	if notes, _ := database.GetNotes(skill.ID); len(notes) > 0 {
+		fmt.Println("Your Notes:")
+		for _, n := range notes {
+			printNote(n)
+		}
+	}
 }
 
 func printSkillInfo(s *skills.Skill) {
@@ -345,80 +485,22 @@ func printSkillInfo(s *skills.Skill) {
 	}
 }
 
-func runSetup(cmd *cobra.Command, args []string) {
-	if runtime.GOOS != "darwin" {
-		fmt.Println("Voice mode is currently only supported on macOS.")
-		fmt.Println("TTS uses the 'say' command and STT uses whisper.cpp.")
-		return
-	}
-
-	fmt.Println("Setting up voice mode for bonk...")
-	fmt.Println()
-
-	// Check for Homebrew
-	if _, err := exec.LookPath("brew"); err != nil {
-		fmt.Println("✗ Homebrew not found")
-		fmt.Println("  Install from: https://brew.sh")
+// printDesignDoc writes the session's design notebook to stdout as markdown.
+func printDesignDoc(session *db.SessionDetail, skillName string) {
+	doc, err := notebook.Parse(session.DesignDoc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading design doc: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println("✓ Homebrew found")
-
-	// Check/install sox
-	if _, err := exec.LookPath("sox"); err != nil {
-		fmt.Println("  Installing sox...")
-		installCmd := exec.Command("brew", "install", "sox")
-		installCmd.Stdout = os.Stdout
-		installCmd.Stderr = os.Stderr
-		if err := installCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "✗ Failed to install sox: %v\n", err)
-			os.Exit(1)
-		}
-	}
-	fmt.Println("✓ sox installed")
-
-	// Check/install whisper-cpp
-	if _, err := exec.LookPath("whisper-cli"); err != nil {
-		fmt.Println("  Installing whisper-cpp...")
-		installCmd := exec.Command("brew", "install", "whisper-cpp")
-		installCmd.Stdout = os.Stdout
-		installCmd.Stderr = os.Stderr
-		if err := installCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "✗ Failed to install whisper-cpp: %v\n", err)
-			os.Exit(1)
-		}
-	}
-	fmt.Println("✓ whisper-cpp installed")
-
-	// Download whisper model
-	homeDir, _ := os.UserHomeDir()
-	bonkDir := filepath.Join(homeDir, ".bonk")
-	modelPath := filepath.Join(bonkDir, "ggml-tiny.en.bin")
-
-	if err := os.MkdirAll(bonkDir, 0755); err != nil {
-		fmt.Fprintf(os.Stderr, "✗ Failed to create ~/.bonk directory: %v\n", err)
+	if doc.Empty() {
+		fmt.Fprintf(os.Stderr, "No design notes in the last %s session.\n", skillName)
 		os.Exit(1)
 	}
-
-	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
-		fmt.Println("  Downloading whisper model (tiny.en, ~39MB)...")
-		curlCmd := exec.Command("curl", "-sSL",
-			"https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.en.bin",
-			"-o", modelPath)
-		curlCmd.Stdout = os.Stdout
-		curlCmd.Stderr = os.Stderr
-		if err := curlCmd.Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "✗ Failed to download model: %v\n", err)
-			os.Exit(1)
-		}
-	}
-	fmt.Println("✓ Whisper model ready")
-
-	fmt.Println("\nVoice mode setup complete!")
-	fmt.Println("Run: bonk --voice")
+	fmt.Print(doc.Markdown(skillName))
 }
 
 func runReview(cmd *cobra.Command, args []string) {
-	database, err := db.Open()
+	database, err := db.Open(cfg.DBPath())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
 		os.Exit(1)
@@ -453,45 +535,223 @@ func runReview(cmd *cobra.Command, args []string) {
 		skillName = skill.Name
 	}
 
+	if doc, _ := cmd.Flags().GetBool("doc"); doc {
+		printDesignDoc(session, skillName)
+		return
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	out, _ := cmd.Flags().GetString("out")
+	wantFeedback, _ := cmd.Flags().GetBool("feedback")
+	if format != "" || out != "" {
+		exportReview(cmd, database, session, format, out, wantFeedback)
+		return
+	}
+
 	// Print session info
 	fmt.Println()
 	fmt.Printf("Session: %s\n", skillName)
 	fmt.Printf("Date: %s\n", session.StartedAt[:10])
-	fmt.Printf("Rating: %d/4\n", session.Rating)
+	fmt.Printf("Rating: %d/4", session.Rating)
+	if session.SelfRating > 0 && session.CoachRating > 0 {
+		fmt.Printf(" (you %d, coach %d)", session.SelfRating, session.CoachRating)
+	}
+	fmt.Println()
+	if session.PromptVersion != "" {
+		fmt.Printf("Prompt: %s\n", session.PromptVersion)
+	}
+	var trajectory []string
+	for _, ex := range session.Exchanges {
+		if ex.CoachRating > 0 {
+			trajectory = append(trajectory, strconv.Itoa(ex.CoachRating))
+		}
+	}
+	if len(trajectory) > 1 {
+		fmt.Printf("Coach rating by turn: %s\n", strings.Join(trajectory, " → "))
+	}
 	fmt.Println()
 	fmt.Println(strings.Repeat("─", 60))
 
-	// Print exchanges
+	// Print exchanges, with a heading whenever a practical interview moves
+	// to another phase
+	phase := ""
 	for _, ex := range session.Exchanges {
+		if !ex.Final && ex.Phase != "" && ex.Phase != phase {
+			phase = ex.Phase
+			fmt.Println()
+			fmt.Printf("== %s ==\n", pacing.Title(phase))
+		}
 		fmt.Println()
-		fmt.Printf("Coach:\n%s\n", ex.Question)
+		if ex.Final {
+			fmt.Printf("Coach (assessment):\n%s\n", ex.Question)
+			fmt.Println()
+			fmt.Println(strings.Repeat("─", 40))
+			continue
+		}
+		label := "Coach"
+		if ex.CoachRating > 0 {
+			label += fmt.Sprintf(" (rating so far %d/4)", ex.CoachRating)
+		}
+		fmt.Printf("%s:\n%s\n", label, ex.Question)
 		fmt.Println()
 		fmt.Printf("You:\n%s\n", ex.Answer)
+		if d := ex.Delivery; d != nil && d.Timed() {
+			fmt.Printf("  [%.0f wpm · %d fillers · %d hedges · %.0f%% pauses]\n",
+				d.WordsPerMinute, d.Fillers, d.Hedges, d.PauseRatio*100)
+		} else if d != nil {
+			fmt.Printf("  [%d fillers · %d hedges]\n", d.Fillers, d.Hedges)
+		}
+		if ex.Check != "" {
+			fmt.Printf("  [check: %s]\n", ex.Check)
+		}
+		if ex.Hints > 0 {
+			fmt.Printf("  [hints: %d]\n", ex.Hints)
+		}
 		fmt.Println()
 		fmt.Println(strings.Repeat("─", 40))
 	}
 
+	printPacing(database, session)
+	printDeliverySummary(database, session)
+	printSessionUsage(database, session)
+
 	// Get AI feedback if requested
-	wantFeedback, _ := cmd.Flags().GetBool("feedback")
 	if wantFeedback {
 		fmt.Println()
 		fmt.Println("Getting AI feedback...")
 		fmt.Println()
+		fmt.Println(sessionFeedback(cmd, database, session))
+	}
+}
 
-		// Convert exchanges to llm format
-		exchanges := make([]llm.ExchangeData, len(session.Exchanges))
-		for i, ex := range session.Exchanges {
-			exchanges[i] = llm.ExchangeData{
-				Question: ex.Question,
-				Answer:   ex.Answer,
-			}
+// exportReview writes the session as a markdown, HTML or JSON document,
+// with the AI feedback included when asked for. The format defaults to the
+// extension of out, then to markdown.
+func exportReview(cmd *cobra.Command, database *db.DB, session *db.SessionDetail, format, out string, wantFeedback bool) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(out)) {
+		case ".html", ".htm":
+			format = "html"
+		case ".json":
+			format = "json"
+		default:
+			format = "md"
 		}
+	}
+	if !slices.Contains(transcript.Formats, format) {
+		fmt.Fprintf(os.Stderr, "Unknown format: %s (use md, html or json)\n", format)
+		os.Exit(1)
+	}
 
-		feedback, err := llm.GetSessionFeedback(session.SkillID, exchanges)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting feedback: %v\n", err)
-			os.Exit(1)
+	t := transcript.New(session)
+	if wantFeedback {
+		fmt.Fprintln(os.Stderr, "Getting AI feedback...")
+		t.Feedback = sessionFeedback(cmd, database, session)
+	}
+	doc, err := t.Render(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error exporting session: %v\n", err)
+		os.Exit(1)
+	}
+	if out == "" {
+		os.Stdout.Write(doc)
+		return
+	}
+	if err := os.WriteFile(out, doc, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", out, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", out)
+}
+
+// sessionFeedback asks the model for feedback on a session, leaving out the
+// closing assessment, and records what it cost.
+func sessionFeedback(cmd *cobra.Command, database *db.DB, session *db.SessionDetail) string {
+	var exchanges []llm.ExchangeData
+	for _, ex := range session.Exchanges {
+		if ex.Final {
+			continue
 		}
-		fmt.Println(feedback)
+		exchanges = append(exchanges, llm.ExchangeData{
+			Question: ex.Question,
+			Answer:   ex.Answer,
+		})
+	}
+
+	if err := checkBudget(database, dayClock(cfg), time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	feedback, err := llm.GetSessionFeedback(cmd.Context(), session.SkillID, exchanges)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting feedback: %v\n", err)
+		os.Exit(1)
+	}
+	database.RecordUsage(db.UsageRecord{
+		SessionID: session.ID,
+		Kind:      db.UsageFeedback,
+		Model:     feedback.Model,
+		Tokens:    tui.DBTokens(feedback.Usage),
+	})
+	return feedback.Text
+}
+
+// printPacing shows how long a practical interview spent in each phase.
+func printPacing(database *db.DB, session *db.SessionDetail) {
+	stats, err := database.GetPhaseStats(session.ID)
+	if err != nil || len(stats) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Pacing:")
+	fmt.Printf("  %-18s %10s %6s %6s %6s\n", "", "time", "budget", "turns", "score")
+	var pstats []pacing.Stat
+	for _, s := range stats {
+		d := time.Duration(s.Seconds * float64(time.Second))
+		pstats = append(pstats, pacing.Stat{Phase: s.Phase, Turns: s.Turns, Duration: d, Score: s.Score})
+		budget := "-"
+		if p, _, ok := pacing.Lookup(s.Phase); ok {
+			budget = fmt.Sprintf("%.0fm", p.Budget.Minutes())
+		}
+		score := "-"
+		if s.Score > 0 {
+			score = fmt.Sprintf("%d/4", s.Score)
+		}
+		fmt.Printf("  %-18s %10s %6s %6d %6s\n", pacing.Title(s.Phase), pacing.FormatDuration(d), budget, s.Turns, score)
+	}
+	for _, w := range pacing.Warnings(pstats) {
+		fmt.Printf("  ! %s\n", w)
+	}
+}
+
+// printDeliverySummary shows the session's voice delivery averages next to
+// the user's recent voice sessions.
+func printDeliverySummary(database *db.DB, session *db.SessionDetail) {
+	summary := db.SummarizeDelivery(session.Exchanges)
+	if summary == nil {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Delivery:")
+	fmt.Printf("  %-18s %6s %8s\n", "", "session", "recent")
+
+	trend, _ := database.GetDeliveryTrend(10)
+	var recent db.DeliverySummary
+	for _, d := range trend {
+		recent.WordsPerMinute += d.WordsPerMinute / float64(len(trend))
+		recent.FillersPerAnswer += d.FillersPerAnswer / float64(len(trend))
+		recent.HedgesPerAnswer += d.HedgesPerAnswer / float64(len(trend))
+		recent.PauseRatio += d.PauseRatio / float64(len(trend))
+	}
+	fmt.Printf("  %-18s %6.0f %8.0f\n", "words/min", summary.WordsPerMinute, recent.WordsPerMinute)
+	fmt.Printf("  %-18s %6.1f %8.1f\n", "fillers/answer", summary.FillersPerAnswer, recent.FillersPerAnswer)
+	fmt.Printf("  %-18s %6.1f %8.1f\n", "hedges/answer", summary.HedgesPerAnswer, recent.HedgesPerAnswer)
+	fmt.Printf("  %-18s %5.0f%% %7.0f%%\n", "pause ratio", summary.PauseRatio*100, recent.PauseRatio*100)
+	if len(trend) > 1 {
+		fmt.Printf("  %-18s ", "wpm trend")
+		for _, d := range trend {
+			fmt.Printf("%.0f ", d.WordsPerMinute)
+		}
+		fmt.Println()
 	}
 }
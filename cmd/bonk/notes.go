@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/db"
+	"bonk/internal/questions"
+	"bonk/internal/skills"
+)
+
+func newNotesCmd() *cobra.Command {
+	notesCmd := &cobra.Command{
+		Use:   "notes [skill]",
+		Short: "List the exchanges you bookmarked and your notes on them",
+		Long: `List the exchanges you bookmarked with ctrl+s during a drill, and the
+notes you attached, for one skill or all of them.
+
+Examples:
+  bonk notes                   All notes, grouped by skill
+  bonk notes hash-maps         Notes for hash-maps
+  bonk notes --md > notes.md   Export as markdown, with the coach's full messages`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  runNotes,
+	}
+	notesCmd.Flags().Bool("md", false, "Print the notes as markdown")
+	return notesCmd
+}
+
+func runNotes(cmd *cobra.Command, args []string) {
+	skillID := ""
+	if len(args) > 0 {
+		if skills.Get(args[0]) == nil {
+			fmt.Fprintf(os.Stderr, "Unknown skill: %s\nUse 'bonk list' to see available skills\n", args[0])
+			os.Exit(1)
+		}
+		skillID = args[0]
+	}
+	markdown, _ := cmd.Flags().GetBool("md")
+
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	notes, err := database.GetNotes(skillID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading notes: %v\n", err)
+		os.Exit(1)
+	}
+	if len(notes) == 0 {
+		fmt.Fprintf(os.Stderr, "No notes yet. Press ctrl+s during a drill to bookmark what the coach said.\n")
+		return
+	}
+
+	if markdown {
+		fmt.Print(notesMarkdown(notes))
+		return
+	}
+	fmt.Println()
+	for i, n := range notes {
+		if i == 0 || n.SkillID != notes[i-1].SkillID {
+			fmt.Printf("%s\n%s\n", skillName(n.SkillID), strings.Repeat("─", 40))
+		}
+		printNote(n)
+	}
+}
+
+// printNote writes one note as a short terminal entry.
+func printNote(n db.Note) {
+	fmt.Printf("  %s · turn %d\n", n.CreatedAt[:min(10, len(n.CreatedAt))], n.Turn)
+	fmt.Printf("  Coach: %s\n", questions.Summary(n.Excerpt))
+	if n.Text != "" {
+		fmt.Printf("  ★ %s\n", n.Text)
+	}
+	fmt.Println()
+}
+
+// notesMarkdown renders notes as a markdown document, one section per
+// skill, quoting the coach's message in full under each note.
+func notesMarkdown(notes []db.Note) string {
+	var b strings.Builder
+	b.WriteString("# bonk notes\n")
+	for i, n := range notes {
+		if i == 0 || n.SkillID != notes[i-1].SkillID {
+			fmt.Fprintf(&b, "\n## %s\n", skillName(n.SkillID))
+		}
+		fmt.Fprintf(&b, "\n### %s, turn %d\n\n", n.CreatedAt[:min(10, len(n.CreatedAt))], n.Turn)
+		if n.Text != "" {
+			b.WriteString(n.Text + "\n\n")
+		}
+		for _, line := range strings.Split(strings.TrimSpace(n.Excerpt), "\n") {
+			b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+		}
+	}
+	return b.String()
+}
+
+// skillName returns a skill's display name, or its ID if it no longer
+// exists.
+func skillName(id string) string {
+	if s := skills.Get(id); s != nil {
+		return s.Name
+	}
+	return id
+}
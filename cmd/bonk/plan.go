@@ -0,0 +1,236 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/db"
+	"bonk/internal/planner"
+	"bonk/internal/skills"
+)
+
+func newPlanCmd() *cobra.Command {
+	planCmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Plan your study days up to an interview date",
+		Long: `Lay out a day-by-day plan up to an interview date: weak skills first,
+then skills you've never practiced, then reviews that fall due before the
+date, with spare time spent revisiting the weakest. While the plan is
+active, bonk drills today's planned skills first and keeps review intervals
+from running past the date.
+
+Without flags, show the active plan.
+
+Examples:
+  bonk plan --until 2026-11-07 --focus sys,lc --minutes 30/day
+  bonk plan
+  bonk plan --clear`,
+		Args: cobra.NoArgs,
+		Run:  runPlan,
+	}
+	planCmd.Flags().String("until", "", "Interview date, YYYY-MM-DD (the last study day)")
+	planCmd.Flags().String("focus", "", "Comma-separated domains to cover (default: all)")
+	planCmd.Flags().String("minutes", "30", "Study time per day, e.g. 30 or 30/day")
+	planCmd.Flags().Bool("clear", false, "Drop the active plan")
+	return planCmd
+}
+
+func runPlan(cmd *cobra.Command, args []string) {
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	if clear, _ := cmd.Flags().GetBool("clear"); clear {
+		cleared, err := database.ClearPlan()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error clearing plan: %v\n", err)
+			os.Exit(1)
+		}
+		if cleared {
+			fmt.Println("Plan cleared.")
+		} else {
+			fmt.Println("No active plan.")
+		}
+		return
+	}
+
+	today := dayClock(cfg).Day(time.Now())
+	until, _ := cmd.Flags().GetString("until")
+	if until == "" {
+		showPlan(database, today)
+		return
+	}
+
+	if _, err := time.Parse(time.DateOnly, until); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --until %q (want YYYY-MM-DD)\n", until)
+		os.Exit(1)
+	}
+	if until < today {
+		fmt.Fprintf(os.Stderr, "--until %s is in the past\n", until)
+		os.Exit(1)
+	}
+	focusFlag, _ := cmd.Flags().GetString("focus")
+	focus, err := parseFocus(focusFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --focus: %v\n", err)
+		os.Exit(1)
+	}
+	minutesFlag, _ := cmd.Flags().GetString("minutes")
+	minutes, err := parseMinutes(minutesFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --minutes: %v\n", err)
+		os.Exit(1)
+	}
+
+	candidates, err := planCandidates(database, focus)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading history: %v\n", err)
+		os.Exit(1)
+	}
+	built := planner.Build(candidates, today, until, minutes)
+
+	plan := db.Plan{Until: until, Focus: focus, MinutesPerDay: minutes}
+	for _, day := range built.Days {
+		pd := db.PlanDay{Date: day.Date}
+		for _, id := range day.Skills {
+			pd.Items = append(pd.Items, db.PlanItem{SkillID: id})
+		}
+		plan.Days = append(plan.Days, pd)
+	}
+	if _, err := database.SavePlan(plan); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving plan: %v\n", err)
+		os.Exit(1)
+	}
+
+	printPlan(&plan, today)
+	if len(built.Unscheduled) > 0 {
+		var names []string
+		for _, id := range built.Unscheduled {
+			names = append(names, skillName(id))
+		}
+		fmt.Printf("Didn't fit: %s\n", strings.Join(names, ", "))
+		fmt.Println("Raise --minutes or narrow --focus to cover them.")
+		fmt.Println()
+	}
+}
+
+func showPlan(database *db.DB, today string) {
+	plan, err := database.GetActivePlan(today)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading plan: %v\n", err)
+		os.Exit(1)
+	}
+	if plan == nil {
+		fmt.Println("No active plan. Make one with: bonk plan --until YYYY-MM-DD")
+		return
+	}
+	printPlan(plan, today)
+}
+
+func printPlan(plan *db.Plan, today string) {
+	focus := "all domains"
+	if len(plan.Focus) > 0 {
+		var names []string
+		for _, d := range plan.Focus {
+			names = append(names, skills.DomainName(d))
+		}
+		focus = strings.Join(names, ", ")
+	}
+	fmt.Printf("\nPlan to %s: %s, %d min/day\n", plan.Until, focus, plan.MinutesPerDay)
+	fmt.Println(strings.Repeat("─", 60))
+	for _, day := range plan.Days {
+		if len(day.Items) == 0 {
+			continue
+		}
+		var items []string
+		for _, item := range day.Items {
+			name := skillName(item.SkillID)
+			if item.Done {
+				name = "✓ " + name
+			}
+			items = append(items, name)
+		}
+		marker := " "
+		if day.Date == today {
+			marker = "▸"
+		}
+		label := day.Date
+		if t, err := time.Parse(time.DateOnly, day.Date); err == nil {
+			label = t.Format("Mon Jan 02")
+		}
+		fmt.Printf("%s %s  %s\n", marker, label, strings.Join(items, ", "))
+	}
+	fmt.Println()
+}
+
+// parseFocus resolves comma-separated domain names and short names.
+func parseFocus(s string) ([]string, error) {
+	var focus []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		domain, ok := skills.DomainMap[part]
+		if !ok {
+			return nil, fmt.Errorf("unknown domain %q", part)
+		}
+		if !seen[domain] {
+			seen[domain] = true
+			focus = append(focus, domain)
+		}
+	}
+	return focus, nil
+}
+
+// parseMinutes accepts 30, 30m or 30/day.
+func parseMinutes(s string) (int, error) {
+	s = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(s), "/day"), "m")
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("want minutes per day, e.g. 30 or 30/day")
+	}
+	return n, nil
+}
+
+// planCandidates gathers the focus skills with their history.
+func planCandidates(database *db.DB, focus []string) ([]planner.Skill, error) {
+	inFocus := map[string]bool{}
+	for _, d := range focus {
+		inFocus[d] = true
+	}
+	scheduling, err := database.GetAllScheduling()
+	if err != nil {
+		return nil, err
+	}
+	var candidates []planner.Skill
+	for _, s := range skills.List() {
+		if len(focus) > 0 && !inFocus[s.Domain] {
+			continue
+		}
+		avg, count, err := database.GetSkillAvgRating(s.ID)
+		if err != nil {
+			return nil, err
+		}
+		c := planner.Skill{ID: s.ID, Domain: s.Domain, Sessions: count, AvgRating: avg}
+		if sched, ok := scheduling[s.ID]; ok {
+			if due, err := time.ParseInLocation(time.DateTime, sched.DueAt, time.UTC); err == nil {
+				c.Due = due
+			}
+		}
+		candidates = append(candidates, c)
+	}
+	// skills.List is in map order; keep plans reproducible.
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].ID < candidates[j].ID })
+	return candidates, nil
+}
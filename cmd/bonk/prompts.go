@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/llm"
+)
+
+func newPromptsCmd() *cobra.Command {
+	promptsCmd := &cobra.Command{
+		Use:   "prompts",
+		Short: "Show the coach's prompt templates and their versions",
+		Long: `The coach's prompts are Go text/template files. A file named
+<name>.tmpl in ~/.bonk/prompts (or $BONK_HOME/prompts) replaces the
+built-in template of that name.
+
+Every session records the version of the template it ran with, shown by
+'bonk review', so a rating can be traced back to the prompt behind it.`,
+		Args: cobra.NoArgs,
+		Run:  runPrompts,
+	}
+
+	promptsCmd.AddCommand(&cobra.Command{
+		Use:   "export [name...]",
+		Short: "Copy built-in templates into the prompts directory for editing",
+		Long: `Copy built-in templates into the prompts directory, where they
+override the built-in ones. Existing files are left alone.`,
+		Run: runPromptsExport,
+	})
+	return promptsCmd
+}
+
+func runPrompts(cmd *cobra.Command, args []string) {
+	infos, err := llm.Prompts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("# overrides: %s\n", llm.PromptDir())
+	for _, p := range infos {
+		source := "built-in"
+		if p.Path != "" {
+			source = p.Path
+		}
+		fmt.Printf("%-10s %-22s %s\n", p.Name, p.Version, source)
+	}
+}
+
+func runPromptsExport(cmd *cobra.Command, args []string) {
+	names := args
+	if len(names) == 0 {
+		names = llm.PromptNames
+	}
+	dir := llm.PromptDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating %s: %v\n", dir, err)
+		os.Exit(1)
+	}
+	for _, name := range names {
+		src, err := llm.DefaultPrompt(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		path := filepath.Join(dir, name+".tmpl")
+		if _, err := os.Stat(path); err == nil {
+			fmt.Printf("%s exists, skipped\n", path)
+			continue
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(path, src, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/config"
+	"bonk/internal/db"
+	"bonk/internal/remind"
+)
+
+func newDueCmd() *cobra.Command {
+	dueCmd := &cobra.Command{
+		Use:   "due",
+		Short: "Show how many reviews are due and whether your streak is at risk",
+		Long: `Show how many skills are due for review and whether today's drill is
+still needed to keep your streak. With --notify, show a desktop notification
+instead, and only when there is something to do; this is what the daily
+reminder from 'bonk remind install' runs.`,
+		Args: cobra.NoArgs,
+		Run:  runDue,
+	}
+	dueCmd.Flags().Bool("notify", false, "Send a desktop notification when reviews are due or the streak is at risk")
+	return dueCmd
+}
+
+func newRemindCmd() *cobra.Command {
+	remindCmd := &cobra.Command{
+		Use:   "remind",
+		Short: "Schedule a daily reminder to drill",
+		Long: `Schedule 'bonk due --notify' to run every day, so a desktop notification
+tells you when reviews are due or your streak is about to break.
+
+Uses a systemd user timer where one is available, otherwise a crontab entry.
+
+Examples:
+  bonk remind install --at 09:00
+  bonk remind install --at 18:30 --method cron
+  bonk remind uninstall`,
+	}
+
+	installCmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install the daily reminder, replacing any earlier one",
+		Args:  cobra.NoArgs,
+		Run:   runRemindInstall,
+	}
+	installCmd.Flags().String("at", "09:00", "Time of day to check, 24-hour HH:MM")
+	installCmd.Flags().String("method", "", "systemd or cron (default: systemd when available)")
+	remindCmd.AddCommand(installCmd)
+
+	remindCmd.AddCommand(&cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the daily reminder",
+		Args:  cobra.NoArgs,
+		Run:   runRemindUninstall,
+	})
+	return remindCmd
+}
+
+// runDue is kept to a few queries: the reminder runs it unattended.
+func runDue(cmd *cobra.Command, args []string) {
+	notify, _ := cmd.Flags().GetBool("notify")
+
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	due, err := database.GetDueCount()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error counting due reviews: %v\n", err)
+		os.Exit(1)
+	}
+	status, err := database.GetStreak(dayClock(cfg))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading streak: %v\n", err)
+		os.Exit(1)
+	}
+
+	msg, ok := remind.Message(due, status)
+	if !notify {
+		if !ok {
+			msg = "Nothing due."
+			if status.Current > 0 {
+				msg += fmt.Sprintf(" %d-day streak, drilled today.", status.Current)
+			}
+		}
+		fmt.Println(msg)
+		return
+	}
+	if !ok {
+		return
+	}
+	if err := remind.Notify("bonk", msg+" Run bonk to start."); err != nil {
+		fmt.Fprintf(os.Stderr, "Error sending notification: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runRemindInstall(cmd *cobra.Command, args []string) {
+	atFlag, _ := cmd.Flags().GetString("at")
+	at, err := remind.ParseAt(atFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
+		os.Exit(1)
+	}
+	method, _ := cmd.Flags().GetString("method")
+	if method == "" {
+		method = remind.DefaultMethod()
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error finding the bonk binary: %v\n", err)
+		os.Exit(1)
+	}
+	// Timers and cron run with a bare environment, so pin the profile and
+	// database this shell is using.
+	job := remind.Job{
+		At:      at,
+		Command: []string{exe, "--db", cfg.DBPath(), "due", "--notify"},
+		Env:     []string{"BONK_HOME=" + config.Home()},
+	}
+	if method == remind.MethodCron {
+		// notify-send needs the session bus, which cron doesn't pass on.
+		if bus := os.Getenv("DBUS_SESSION_BUS_ADDRESS"); bus != "" {
+			job.Env = append(job.Env, "DBUS_SESSION_BUS_ADDRESS="+bus)
+		}
+	}
+
+	where, err := remind.Install(method, job)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error installing reminder: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Reminder set for %s every day (%s).\n", atFlag, where)
+	fmt.Println("Try it now with: bonk due --notify")
+	fmt.Println("Remove it with:  bonk remind uninstall")
+}
+
+func runRemindUninstall(cmd *cobra.Command, args []string) {
+	removed, err := remind.Uninstall()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error removing reminder: %v\n", err)
+		os.Exit(1)
+	}
+	if len(removed) == 0 {
+		fmt.Println("No reminder installed.")
+		return
+	}
+	fmt.Printf("Removed %s.\n", strings.Join(removed, " and "))
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/voice"
+)
+
+// packageManager knows how to install packages on the host system.
+type packageManager struct {
+	name    string
+	install []string          // install command prefix, package appended
+	names   map[string]string // bonk dependency -> package name (missing = unavailable)
+}
+
+var packageManagers = []packageManager{
+	{
+		name:    "brew",
+		install: []string{"brew", "install"},
+		names:   map[string]string{"sox": "sox", "whisper": "whisper-cpp", "espeak": "espeak-ng"},
+	},
+	{
+		name:    "apt-get",
+		install: []string{"apt-get", "install", "-y"},
+		names:   map[string]string{"sox": "sox", "arecord": "alsa-utils", "espeak": "espeak-ng"},
+	},
+	{
+		name:    "dnf",
+		install: []string{"dnf", "install", "-y"},
+		names:   map[string]string{"sox": "sox", "arecord": "alsa-utils", "espeak": "espeak-ng"},
+	},
+	{
+		name:    "pacman",
+		install: []string{"pacman", "-S", "--noconfirm", "--needed"},
+		names:   map[string]string{"sox": "sox", "arecord": "alsa-utils", "espeak": "espeak-ng"},
+	},
+	{
+		name:    "zypper",
+		install: []string{"zypper", "--non-interactive", "install"},
+		names:   map[string]string{"sox": "sox", "arecord": "alsa-utils", "espeak": "espeak-ng"},
+	},
+}
+
+// detectPackageManager returns the first supported package manager on PATH.
+// On macOS only Homebrew is considered.
+func detectPackageManager() *packageManager {
+	for i := range packageManagers {
+		pm := &packageManagers[i]
+		if runtime.GOOS == "darwin" && pm.name != "brew" {
+			continue
+		}
+		if _, err := exec.LookPath(pm.name); err == nil {
+			return pm
+		}
+	}
+	return nil
+}
+
+// installPackage installs a bonk dependency, using sudo for system package
+// managers when not already root.
+func (pm *packageManager) installPackage(dep string) error {
+	pkg, ok := pm.names[dep]
+	if !ok {
+		return fmt.Errorf("%s has no package for %s", pm.name, dep)
+	}
+	args := append(append([]string{}, pm.install...), pkg)
+	if pm.name != "brew" && os.Geteuid() != 0 {
+		if _, err := exec.LookPath("sudo"); err == nil {
+			args = append([]string{"sudo"}, args...)
+		}
+	}
+	fmt.Printf("  Installing %s...\n", pkg)
+	installCmd := exec.Command(args[0], args[1:]...)
+	installCmd.Stdin = os.Stdin
+	installCmd.Stdout = os.Stdout
+	installCmd.Stderr = os.Stderr
+	return installCmd.Run()
+}
+
+func hasAnyBinary(names ...string) bool {
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func runSetup(cmd *cobra.Command, args []string) {
+	if runtime.GOOS != "darwin" && runtime.GOOS != "linux" {
+		fmt.Printf("Voice mode is not supported on %s.\n", runtime.GOOS)
+		return
+	}
+
+	fmt.Println("Setting up voice mode for bonk...")
+	fmt.Println()
+
+	pm := detectPackageManager()
+	if pm == nil {
+		if runtime.GOOS == "darwin" {
+			fmt.Println("✗ Homebrew not found")
+			fmt.Println("  Install from: https://brew.sh")
+		} else {
+			fmt.Println("✗ No supported package manager found (apt, dnf, pacman, zypper, brew)")
+			fmt.Println("  Install sox, espeak-ng and whisper.cpp manually")
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("✓ %s found\n", pm.name)
+
+	// Check/install sox (audio recording)
+	if !hasAnyBinary("sox") {
+		if err := pm.installPackage("sox"); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ Failed to install sox: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	fmt.Println("✓ sox installed")
+
+	// Check/install espeak-ng (Linux TTS; macOS has `say` built in)
+	if runtime.GOOS == "linux" {
+		if !hasAnyBinary("espeak-ng", "espeak", "piper") {
+			if err := pm.installPackage("espeak"); err != nil {
+				fmt.Fprintf(os.Stderr, "✗ Failed to install espeak-ng: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		fmt.Println("✓ text-to-speech installed")
+	}
+
+	// Check/install whisper-cpp
+	if !hasAnyBinary(voice.WhisperBinaries...) {
+		if _, ok := pm.names["whisper"]; !ok {
+			fmt.Println("✗ whisper.cpp is not packaged for " + pm.name)
+			fmt.Println("  Build it from source and put whisper-cli on your PATH:")
+			fmt.Println("    git clone https://github.com/ggml-org/whisper.cpp")
+			fmt.Println("    cd whisper.cpp && cmake -B build && cmake --build build -j --config Release")
+			fmt.Println("    sudo cp build/bin/whisper-cli /usr/local/bin/")
+			fmt.Println("  Then re-run: bonk setup")
+			os.Exit(1)
+		}
+		if err := pm.installPackage("whisper"); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ Failed to install whisper-cpp: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	fmt.Println("✓ whisper-cpp installed")
+
+	// Download whisper model
+	settings := voiceSettings(cfg)
+	modelPath := settings.ModelPath
+	if err := os.MkdirAll(filepath.Dir(modelPath), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "✗ Failed to create %s: %v\n", filepath.Dir(modelPath), err)
+		os.Exit(1)
+	}
+
+	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
+		model, known := voice.LookupWhisperModel(settings.WhisperModel)
+		if !known {
+			fmt.Fprintf(os.Stderr, "✗ Unknown whisper model %q\n", settings.WhisperModel)
+			fmt.Fprintln(os.Stderr, "  Known models:")
+			for _, m := range voice.WhisperModels {
+				fmt.Fprintf(os.Stderr, "    %-16s %s\n", m.Name, m.Size)
+			}
+			fmt.Fprintln(os.Stderr, "  Or download one yourself and set voice.whisper_model_path")
+			os.Exit(1)
+		}
+		if err := downloadModel(model, modelPath); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ Failed to download model: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("✓ Whisper model ready (%s)\n", settings.WhisperModel)
+	if m, ok := voice.LookupWhisperModel(settings.WhisperModel); ok && m.EnglishOnly() && settings.Language != "en" && settings.Language != "" {
+		fmt.Printf("  Note: %s only transcribes English; language %q needs a multilingual model\n", m.Name, settings.Language)
+	}
+
+	fmt.Println("\nVoice mode setup complete!")
+	fmt.Println("Run: bonk --voice")
+}
+
+// downloadModel fetches a whisper model to a temporary file, verifies its
+// checksum and moves it into place so an interrupted download is never used.
+func downloadModel(model voice.WhisperModel, path string) error {
+	fmt.Printf("  Downloading whisper model (%s, ~%s)...\n", model.Name, model.Size)
+	partial := path + ".part"
+	curlCmd := exec.Command("curl", "-fSL", "--progress-bar", model.URL(), "-o", partial)
+	curlCmd.Stdout = os.Stdout
+	curlCmd.Stderr = os.Stderr
+	if err := curlCmd.Run(); err != nil {
+		os.Remove(partial)
+		return err
+	}
+	if err := voice.VerifyChecksum(partial, model.SHA1); err != nil {
+		os.Remove(partial)
+		return err
+	}
+	return os.Rename(partial, path)
+}
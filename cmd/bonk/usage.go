@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bonk/internal/db"
+	"bonk/internal/llm"
+	"bonk/internal/skills"
+	"bonk/internal/streak"
+	"bonk/internal/tui"
+)
+
+// usageTotal accumulates tokens and estimated cost for one report line.
+type usageTotal struct {
+	calls   int
+	tokens  db.TokenUsage
+	cost    float64
+	unknown bool // some usage was on a model without a known price
+}
+
+func (t *usageTotal) add(r db.UsageRow) {
+	t.calls += r.Calls
+	t.tokens = t.tokens.Add(r.Tokens)
+	cost, ok := llm.Cost(r.Model, tui.LLMUsage(r.Tokens))
+	t.cost += cost
+	t.unknown = t.unknown || !ok
+}
+
+func addUsage(m map[string]*usageTotal, key string, r db.UsageRow) {
+	if m[key] == nil {
+		m[key] = &usageTotal{}
+	}
+	m[key].add(r)
+}
+
+func (t usageTotal) costString() string {
+	s := fmt.Sprintf("$%.2f", t.cost)
+	if t.unknown {
+		s += "+"
+	}
+	return s
+}
+
+func runUsage(cmd *cobra.Command, args []string) {
+	days, _ := cmd.Flags().GetInt("days")
+	if days < 1 {
+		fmt.Fprintf(os.Stderr, "--days must be at least 1\n")
+		os.Exit(1)
+	}
+
+	database, err := db.Open(cfg.DBPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	c := dayClock(cfg)
+	now := time.Now()
+	since := c.StartOfDay(now).AddDate(0, 0, -(days - 1))
+	rows, err := database.GetUsage(c, since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading usage: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	printBudgetStatus(database, c, now)
+
+	if len(rows) == 0 {
+		fmt.Printf("No API usage in the last %d days.\n", days)
+		return
+	}
+
+	var total usageTotal
+	byDay := make(map[string]*usageTotal)
+	byDomain := make(map[string]*usageTotal)
+	bySkill := make(map[string]*usageTotal)
+	var dayOrder []string
+	for _, r := range rows {
+		if byDay[r.Day] == nil {
+			byDay[r.Day] = &usageTotal{}
+			dayOrder = append(dayOrder, r.Day)
+		}
+		domain := "unknown"
+		if s := skills.Get(r.SkillID); s != nil {
+			domain = s.Domain
+		}
+		byDay[r.Day].add(r)
+		addUsage(byDomain, domain, r)
+		addUsage(bySkill, r.SkillID, r)
+		total.add(r)
+	}
+
+	fmt.Printf("Last %d days: %s tokens, ~%s\n", days, formatTokens(total.tokens.Total()), total.costString())
+
+	fmt.Println("\nBy day:")
+	printUsageHeader()
+	for _, day := range dayOrder {
+		printUsageLine(day, byDay[day])
+	}
+
+	fmt.Println("\nBy domain:")
+	printUsageHeader()
+	for _, key := range sortedByCost(byDomain) {
+		printUsageLine(key, byDomain[key])
+	}
+
+	fmt.Println("\nBy skill:")
+	printUsageHeader()
+	for _, key := range sortedByCost(bySkill) {
+		printUsageLine(key, bySkill[key])
+	}
+
+	if total.unknown {
+		fmt.Println("\n+ includes models without a known price")
+	}
+}
+
+func printUsageHeader() {
+	fmt.Printf("  %-28s %6s %10s %10s %9s\n", "", "calls", "input", "output", "cost")
+}
+
+func printUsageLine(label string, t *usageTotal) {
+	input := t.tokens.Input + t.tokens.CacheWrite + t.tokens.CacheRead
+	fmt.Printf("  %-28s %6d %10s %10s %9s\n", label, t.calls,
+		formatTokens(input), formatTokens(t.tokens.Output), t.costString())
+}
+
+func sortedByCost(m map[string]*usageTotal) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if m[keys[i]].cost != m[keys[j]].cost {
+			return m[keys[i]].cost > m[keys[j]].cost
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// budget is a token limit over a calendar period.
+type budget struct {
+	name  string // "daily" or "monthly"
+	key   string // config key
+	limit int
+	since time.Time
+}
+
+// budgets starts each period at the practice-day rollover, so a late-night
+// session counts against the same day as the rest of it.
+func budgets(c streak.Clock, now time.Time) []budget {
+	today := c.StartOfDay(now)
+	return []budget{
+		{"daily", "budget.daily_tokens", cfg.Int("budget.daily_tokens"), today},
+		{"monthly", "budget.monthly_tokens", cfg.Int("budget.monthly_tokens"), today.AddDate(0, 0, 1-today.Day())},
+	}
+}
+
+// checkBudget returns an error describing the first exhausted token budget.
+func checkBudget(database *db.DB, c streak.Clock, now time.Time) error {
+	for _, b := range budgets(c, now) {
+		if b.limit <= 0 {
+			continue
+		}
+		used, err := database.GetTokensSince(b.since)
+		if err != nil {
+			return fmt.Errorf("check %s budget: %w", b.name, err)
+		}
+		if used >= b.limit {
+			return fmt.Errorf("%s token budget reached: %s of %s tokens used\n"+
+				"See 'bonk usage', or raise it with: bonk config set %s <tokens>",
+				b.name, formatTokens(used), formatTokens(b.limit), b.key)
+		}
+	}
+	return nil
+}
+
+func printBudgetStatus(database *db.DB, c streak.Clock, now time.Time) {
+	shown := false
+	for _, b := range budgets(c, now) {
+		if b.limit <= 0 {
+			continue
+		}
+		used, _ := database.GetTokensSince(b.since)
+		fmt.Printf("%-8s budget: %s / %s tokens (%.0f%%)\n", b.name, formatTokens(used), formatTokens(b.limit),
+			float64(used)/float64(b.limit)*100)
+		shown = true
+	}
+	if shown {
+		fmt.Println()
+	}
+}
+
+// formatTokens renders 1234567 as 1.23M and 45600 as 45.6k.
+func formatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.2fM", float64(n)/1e6)
+	case n >= 10_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// printSessionUsage shows what a reviewed session cost.
+func printSessionUsage(database *db.DB, session *db.SessionDetail) {
+	byModel, err := database.GetSessionUsage(session.ID)
+	if err != nil || len(byModel) == 0 {
+		return
+	}
+	var total usageTotal
+	for model, tokens := range byModel {
+		total.add(db.UsageRow{Model: model, Tokens: tokens})
+	}
+	fmt.Println()
+	fmt.Printf("Usage: %s tokens, ~%s\n", formatTokens(total.tokens.Total()), total.costString())
+}
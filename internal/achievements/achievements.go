@@ -0,0 +1,101 @@
+// Package achievements defines the milestones bonk tracks and works out
+// progress toward each from a snapshot of the user's history.
+package achievements
+
+import (
+	"bonk/internal/skills"
+)
+
+// Facts is the history achievements are judged on.
+type Facts struct {
+	Sessions       int            // finished sessions
+	DomainSessions map[string]int // finished sessions per domain
+	Practiced      map[string]int // distinct skills practiced per domain
+	GoodSkills     int            // skills whose last rating was Good or Easy
+	EasyUnassisted int            // sessions rated Easy without hints or reveals
+	LongestStreak  int            // in days
+}
+
+// Achievement is a milestone with a numeric goal.
+type Achievement struct {
+	ID          string
+	Name        string
+	Description string
+	Goal        int
+	count       func(Facts) int
+}
+
+// Progress returns how far f is toward the goal, capped at Goal.
+func (a Achievement) Progress(f Facts) int {
+	return min(a.count(f), a.Goal)
+}
+
+// Done reports whether f meets the goal.
+func (a Achievement) Done(f Facts) bool {
+	return a.count(f) >= a.Goal
+}
+
+// All lists every achievement in display order.
+var All = build()
+
+func build() []Achievement {
+	all := []Achievement{
+		{ID: "first-drill", Name: "First Steps", Description: "Finish your first drill", Goal: 1,
+			count: func(f Facts) int { return f.Sessions }},
+		{ID: "first-interview", Name: "Mock Interview", Description: "Finish a system design practical interview", Goal: 1,
+			count: func(f Facts) int { return f.DomainSessions["system-design-practical"] }},
+		{ID: "sessions-50", Name: "Regular", Description: "Finish 50 drills", Goal: 50,
+			count: func(f Facts) int { return f.Sessions }},
+		{ID: "good-10", Name: "Solid Ten", Description: "Get 10 skills to Good or better", Goal: 10,
+			count: func(f Facts) int { return f.GoodSkills }},
+		{ID: "good-30", Name: "Deep Bench", Description: "Get 30 skills to Good or better", Goal: 30,
+			count: func(f Facts) int { return f.GoodSkills }},
+		{ID: "unassisted-10", Name: "No Training Wheels", Description: "Rate 10 drills Easy without hints or reveals", Goal: 10,
+			count: func(f Facts) int { return f.EasyUnassisted }},
+		{ID: "streak-7", Name: "Week Streak", Description: "Drill 7 days in a row", Goal: 7,
+			count: func(f Facts) int { return f.LongestStreak }},
+		{ID: "streak-30", Name: "Month Streak", Description: "Drill 30 days in a row", Goal: 30,
+			count: func(f Facts) int { return f.LongestStreak }},
+		{ID: "all-domains", Name: "Well Rounded", Description: "Drill a skill in every domain", Goal: len(skills.Domains()),
+			count: func(f Facts) int {
+				n := 0
+				for _, d := range skills.Domains() {
+					if f.Practiced[d] > 0 {
+						n++
+					}
+				}
+				return n
+			}},
+	}
+	for _, d := range skills.Domains() {
+		all = append(all, Achievement{
+			ID:          "domain-" + d,
+			Name:        skills.DomainName(d) + " Covered",
+			Description: "Practice every " + skills.DomainName(d) + " skill",
+			Goal:        len(skills.ListByDomain(d)),
+			count:       func(f Facts) int { return f.Practiced[d] },
+		})
+	}
+	return all
+}
+
+// Get returns the achievement with the given ID.
+func Get(id string) (Achievement, bool) {
+	for _, a := range All {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Achievement{}, false
+}
+
+// Newly returns the achievements f meets that aren't in unlocked yet.
+func Newly(f Facts, unlocked map[string]bool) []Achievement {
+	var out []Achievement
+	for _, a := range All {
+		if !unlocked[a.ID] && a.Done(f) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
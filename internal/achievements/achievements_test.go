@@ -0,0 +1,69 @@
+package achievements
+
+import (
+	"testing"
+
+	"bonk/internal/skills"
+)
+
+func ids(as []Achievement) []string {
+	var out []string
+	for _, a := range as {
+		out = append(out, a.ID)
+	}
+	return out
+}
+
+func TestNewly(t *testing.T) {
+	f := Facts{
+		Sessions:       12,
+		DomainSessions: map[string]int{"system-design-practical": 1, "data-structures": 11},
+		Practiced:      map[string]int{"system-design-practical": 1, "data-structures": 3},
+		GoodSkills:     10,
+		LongestStreak:  6,
+	}
+	got := ids(Newly(f, map[string]bool{"first-drill": true}))
+	want := []string{"first-interview", "good-10"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Newly = %v, want %v", got, want)
+	}
+
+	f.LongestStreak = 7
+	if got := ids(Newly(f, map[string]bool{"first-drill": true, "first-interview": true, "good-10": true})); len(got) != 1 || got[0] != "streak-7" {
+		t.Errorf("Newly after a 7-day streak = %v", got)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	a, ok := Get("domain-estimation")
+	if !ok {
+		t.Fatal("no domain-estimation achievement")
+	}
+	total := len(skills.ListByDomain("estimation"))
+	if a.Goal != total || total == 0 {
+		t.Fatalf("goal = %d, want %d", a.Goal, total)
+	}
+	f := Facts{Practiced: map[string]int{"estimation": total + 3}}
+	if a.Progress(f) != total || !a.Done(f) {
+		t.Errorf("progress = %d, done = %v", a.Progress(f), a.Done(f))
+	}
+
+	rounded, _ := Get("all-domains")
+	f = Facts{Practiced: map[string]int{"estimation": 1, "data-structures": 2}}
+	if rounded.Progress(f) != 2 || rounded.Done(f) {
+		t.Errorf("all-domains progress = %d", rounded.Progress(f))
+	}
+}
+
+func TestIDsAreUnique(t *testing.T) {
+	seen := map[string]bool{}
+	for _, a := range All {
+		if seen[a.ID] {
+			t.Errorf("duplicate achievement %s", a.ID)
+		}
+		seen[a.ID] = true
+		if a.Goal < 1 {
+			t.Errorf("%s has goal %d", a.ID, a.Goal)
+		}
+	}
+}
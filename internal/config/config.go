@@ -0,0 +1,289 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Key describes a setting that can appear in the config file.
+type Key struct {
+	Name    string // "section.key" as written in config.toml
+	Default string
+	Env     string             // environment variable that overrides the file, if any
+	Int     bool               // value must be an integer
+	Bool    bool               // value must be true or false
+	Choices []string           // allowed values, if limited
+	Secret  bool               // masked by `bonk config list`
+	Check   func(string) error // further validation, if any
+	Usage   string
+}
+
+// Keys lists every supported setting.
+var Keys = []Key{
+	{Name: "llm.api_key", Env: "ANTHROPIC_API_KEY", Secret: true, Usage: "Anthropic API key"},
+	{Name: "llm.model", Default: "claude-sonnet-4-20250514", Env: "BONK_MODEL", Usage: "Claude model used for drills and feedback"},
+	{Name: "llm.max_tokens", Default: "1024", Int: true, Usage: "max tokens per coach reply"},
+	{Name: "llm.feedback_max_tokens", Default: "2048", Int: true, Usage: "max tokens for `bonk review --feedback`"},
+	{Name: "llm.timeout_seconds", Default: "90", Int: true, Usage: "give up on a single API request after this long"},
+	{Name: "llm.cassette", Env: "BONK_LLM_CASSETTE", Usage: "record or replay API traffic for offline development"},
+	{Name: "llm.cassette_file", Env: "BONK_LLM_CASSETTE_FILE", Usage: "cassette file (default <home>/cassette.json)"},
+	{Name: "drill.max_turns", Default: "20", Int: true, Usage: "turns before the coach is asked to wrap up"},
+	{Name: "drill.practical_max_turns", Default: "40", Int: true, Usage: "max turns for system design practical interviews"},
+	{Name: "budget.daily_tokens", Default: "0", Int: true, Usage: "block new drills after this many tokens per day (0 = no limit)"},
+	{Name: "budget.monthly_tokens", Default: "0", Int: true, Usage: "block new drills after this many tokens per calendar month (0 = no limit)"},
+	{Name: "rating.blend", Default: "weighted", Choices: []string{"coach", "self", "weighted"}, Usage: "rating sessions are scheduled by: the coach's, your own, or a weighted blend"},
+	{Name: "rating.self_weight", Default: "50", Int: true, Usage: "percent weight of your own rating in the weighted blend"},
+	{Name: "day.timezone", Check: checkTimezone, Usage: "IANA time zone days and streaks are counted in (default: the system's)"},
+	{Name: "day.rollover_hour", Default: "4", Int: true, Check: checkHour, Usage: "hour a new day starts; drills before it count toward the day before"},
+	{Name: "goal.weekly_sessions", Default: "5", Int: true, Usage: "sessions a week to aim for, shown on the welcome screen (0 = no goal)"},
+	{Name: "db.path", Env: "BONK_DB", Usage: "SQLite database file (default <home>/data.sqlite)"},
+	{Name: "voice.whisper_model", Default: "tiny.en", Usage: "whisper.cpp model size (tiny.en, base, small, medium, large-v3, ...)"},
+	{Name: "voice.whisper_model_path", Usage: "whisper model file (default <home>/ggml-<model>.bin)"},
+	{Name: "voice.language", Default: "en", Usage: "spoken language code for transcription, or auto"},
+	{Name: "voice.tts_voice", Usage: "TTS voice name (say -v, espeak-ng -v) or piper .onnx model"},
+	{Name: "voice.speech_rate", Default: "280", Int: true, Check: checkPositive, Usage: "coach speech rate in words per minute"},
+	{Name: "voice.hands_free", Default: "false", Bool: true, Usage: "listen after the coach speaks and send when you stop talking"},
+	{Name: "voice.vad_silence", Default: "1500", Int: true, Check: checkPositive, Usage: "milliseconds of silence that end an answer in hands-free mode"},
+}
+
+// LookupKey returns the registered key with the given name.
+func LookupKey(name string) (Key, bool) {
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, true
+		}
+	}
+	return Key{}, false
+}
+
+// Validate checks that value is acceptable for the named setting.
+func Validate(name, value string) error {
+	k, ok := LookupKey(name)
+	if !ok {
+		return fmt.Errorf("unknown setting %q", name)
+	}
+	if k.Int {
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s must be an integer, got %q", name, value)
+		}
+	}
+	if k.Bool && value != "true" && value != "false" {
+		return fmt.Errorf("%s must be true or false, got %q", name, value)
+	}
+	if len(k.Choices) > 0 {
+		for _, c := range k.Choices {
+			if value == c {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s must be one of %s, got %q", name, strings.Join(k.Choices, ", "), value)
+	}
+	if k.Check != nil {
+		if err := k.Check(value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func checkTimezone(value string) error {
+	if _, err := time.LoadLocation(value); err != nil {
+		return fmt.Errorf("unknown time zone %q", value)
+	}
+	return nil
+}
+
+func checkHour(value string) error {
+	if n, _ := strconv.Atoi(value); n < 0 || n > 23 {
+		return fmt.Errorf("must be an hour from 0 to 23, got %s", value)
+	}
+	return nil
+}
+
+func checkPositive(value string) error {
+	if n, _ := strconv.Atoi(value); n < 1 {
+		return fmt.Errorf("must be greater than 0, got %s", value)
+	}
+	return nil
+}
+
+// Source records which layer a setting's value came from.
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Config holds settings layered as defaults < config file < environment < flags.
+type Config struct {
+	path    string
+	values  map[string]string
+	sources map[string]Source
+}
+
+// Home returns bonk's data directory: $BONK_HOME, or ~/.bonk.
+// Pointing BONK_HOME elsewhere gives a separate profile with its own
+// config file and database.
+func Home() string {
+	if dir := os.Getenv("BONK_HOME"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".bonk")
+}
+
+// DefaultPath returns <home>/config.toml.
+func DefaultPath() string {
+	return filepath.Join(Home(), "config.toml")
+}
+
+// Load reads the config file at path on top of the built-in defaults, then
+// applies environment overrides. A missing file is not an error.
+func Load(path string) (*Config, error) {
+	c := &Config{
+		path:    path,
+		values:  make(map[string]string),
+		sources: make(map[string]Source),
+	}
+	for _, k := range Keys {
+		c.values[k.Name] = k.Default
+		c.sources[k.Name] = SourceDefault
+	}
+
+	fileValues, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for name, v := range fileValues {
+		if err := Validate(name, v); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		c.values[name] = v
+		c.sources[name] = SourceFile
+	}
+
+	for _, k := range Keys {
+		if k.Env == "" {
+			continue
+		}
+		if v := os.Getenv(k.Env); v != "" {
+			if err := Validate(k.Name, v); err != nil {
+				return nil, fmt.Errorf("$%s: %w", k.Env, err)
+			}
+			c.values[k.Name] = v
+			c.sources[k.Name] = SourceEnv
+		}
+	}
+	return c, nil
+}
+
+// Path returns the file the config was loaded from.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Set overrides a setting from a command-line flag.
+func (c *Config) Set(name, value string) error {
+	if err := Validate(name, value); err != nil {
+		return err
+	}
+	c.values[name] = value
+	c.sources[name] = SourceFlag
+	return nil
+}
+
+// String returns a setting's value.
+func (c *Config) String(name string) string {
+	return c.values[name]
+}
+
+// Int returns a setting as an integer, falling back to its default when
+// the configured value doesn't parse.
+func (c *Config) Int(name string) int {
+	if n, err := strconv.Atoi(c.values[name]); err == nil {
+		return n
+	}
+	k, _ := LookupKey(name)
+	n, _ := strconv.Atoi(k.Default)
+	return n
+}
+
+// Bool returns a setting as a boolean.
+func (c *Config) Bool(name string) bool {
+	return c.values[name] == "true"
+}
+
+// Source reports which layer a setting's value came from.
+func (c *Config) Source(name string) Source {
+	return c.sources[name]
+}
+
+// DBPath returns the database file, defaulting to <home>/data.sqlite.
+func (c *Config) DBPath() string {
+	if p := c.String("db.path"); p != "" {
+		return p
+	}
+	return filepath.Join(Home(), "data.sqlite")
+}
+
+// SetInFile writes a single setting to the config file at path, keeping
+// the other settings already in it. An empty value removes the setting.
+// Comments in the file are not preserved.
+func SetInFile(path, name, value string) error {
+	if value != "" {
+		if err := Validate(name, value); err != nil {
+			return err
+		}
+	} else if _, ok := LookupKey(name); !ok {
+		return fmt.Errorf("unknown setting %q", name)
+	}
+
+	values, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		delete(values, name)
+	} else {
+		values[name] = value
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	// The file may hold an API key, so keep it private.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(formatTOML(values)), 0600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+func readFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open config: %w", err)
+	}
+	defer f.Close()
+
+	values, err := parseTOML(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for name := range values {
+		if _, ok := LookupKey(name); !ok {
+			return nil, fmt.Errorf("%s: unknown setting %q", path, name)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLayers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	data := `# practice profile
+[llm]
+model = "file-model"   # overridden by env below
+max_tokens = 512
+
+[drill]
+max_turns = 10
+`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BONK_MODEL", "env-model")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := c.Set("drill.max_turns", "15"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		want   string
+		source Source
+	}{
+		{"llm.model", "env-model", SourceEnv},
+		{"llm.max_tokens", "512", SourceFile},
+		{"llm.feedback_max_tokens", "2048", SourceDefault},
+		{"drill.max_turns", "15", SourceFlag},
+	}
+	for _, tt := range tests {
+		if got := c.String(tt.key); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.key, got, tt.want)
+		}
+		if got := c.Source(tt.key); got != tt.source {
+			t.Errorf("%s source = %s, want %s", tt.key, got, tt.source)
+		}
+	}
+}
+
+func TestLoadRejectsBadValues(t *testing.T) {
+	for _, data := range []string{
+		"[drill]\nmax_turns = \"lots\"\n",
+		"[llm]\nunknown = 1\n",
+		"[llm\nmodel = \"x\"\n",
+		"[rating]\nblend = \"average\"\n",
+		"[day]\nrollover_hour = 24\n",
+		"[day]\ntimezone = \"Mars/Olympus\"\n",
+		"[voice]\nspeech_rate = 0\n",
+		"[voice]\nhands_free = \"yes\"\n",
+		"[voice]\nvad_silence = 0\n",
+	} {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%q) succeeded, want error", data)
+		}
+	}
+}
+
+func TestSetInFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := SetInFile(path, "voice.tts_voice", `Anna "de"`); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInFile(path, "drill.max_turns", "30"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetInFile(path, "drill.max_turns", "x"); err == nil {
+		t.Error("SetInFile accepted a non-integer for an integer setting")
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := c.String("voice.tts_voice"); got != `Anna "de"` {
+		t.Errorf("tts_voice = %q", got)
+	}
+	if got := c.Int("drill.max_turns"); got != 30 {
+		t.Errorf("max_turns = %d, want 30", got)
+	}
+
+	if err := SetInFile(path, "drill.max_turns", ""); err != nil {
+		t.Fatal(err)
+	}
+	c, _ = Load(path)
+	if c.Source("drill.max_turns") != SourceDefault {
+		t.Errorf("max_turns still set after removing it")
+	}
+}
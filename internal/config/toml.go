@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseTOML reads the small TOML subset bonk's config uses: comments,
+// [section] headers and `key = value` pairs with string, number or boolean
+// values. Keys are returned flattened as "section.key".
+func parseTOML(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	section := ""
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header", lineNo)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty section name", lineNo)
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: missing key", lineNo)
+		}
+		value, err := parseValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		if section != "" {
+			key = section + "." + key
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
+
+// stripComment removes a trailing # comment that is not inside a string.
+func stripComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseValue(raw string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("missing value")
+	}
+	switch raw[0] {
+	case '"':
+		s, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", fmt.Errorf("invalid string %s", raw)
+		}
+		return s, nil
+	case '\'':
+		if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+			return "", fmt.Errorf("invalid string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	}
+	if raw == "true" || raw == "false" {
+		return raw, nil
+	}
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64); err == nil {
+		return strings.ReplaceAll(raw, "_", ""), nil
+	}
+	return "", fmt.Errorf("unsupported value %s (quote strings)", raw)
+}
+
+// formatTOML writes flattened "section.key" values back out as TOML, in the
+// order settings are declared in Keys.
+func formatTOML(values map[string]string) string {
+	var b strings.Builder
+	section := ""
+	for _, k := range Keys {
+		v, ok := values[k.Name]
+		if !ok {
+			continue
+		}
+		sec, name, found := strings.Cut(k.Name, ".")
+		if !found {
+			sec, name = "", k.Name
+		}
+		if sec != section {
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "[%s]\n", sec)
+			section = sec
+		}
+		if k.Int || k.Bool {
+			fmt.Fprintf(&b, "%s = %s\n", name, v)
+		} else {
+			fmt.Fprintf(&b, "%s = %s\n", name, strconv.Quote(v))
+		}
+	}
+	return b.String()
+}
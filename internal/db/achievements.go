@@ -0,0 +1,115 @@
+package db
+
+import (
+	"fmt"
+
+	"bonk/internal/achievements"
+	"bonk/internal/skills"
+	"bonk/internal/streak"
+)
+
+// GetAchievementFacts gathers the history achievements are judged on.
+// Only coached drills count as sessions: recall sessions are self-graded,
+// so they would hand out interview and Easy achievements for free. They
+// still count toward the streak and, through scheduling, good skills.
+func (db *DB) GetAchievementFacts(c streak.Clock) (achievements.Facts, error) {
+	f := achievements.Facts{
+		DomainSessions: map[string]int{},
+		Practiced:      map[string]int{},
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT skill_id, COUNT(*) FROM sessions
+		WHERE finished_at IS NOT NULL AND mode = ?
+		GROUP BY skill_id
+	`, SessionDrill)
+	if err != nil {
+		return f, fmt.Errorf("query sessions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var skillID string
+		var n int
+		if err := rows.Scan(&skillID, &n); err != nil {
+			return f, err
+		}
+		f.Sessions += n
+		if s := skills.Get(skillID); s != nil {
+			f.DomainSessions[s.Domain] += n
+			f.Practiced[s.Domain]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return f, err
+	}
+
+	if err := db.conn.QueryRow(`
+		SELECT COUNT(*) FROM scheduling WHERE last_rating >= 3
+	`).Scan(&f.GoodSkills); err != nil {
+		return f, fmt.Errorf("count good skills: %w", err)
+	}
+	if err := db.conn.QueryRow(`
+		SELECT COUNT(*) FROM sessions
+		WHERE finished_at IS NOT NULL AND mode = ? AND rating = 4
+			AND COALESCE(hints_used, 0) = 0 AND COALESCE(reveals, 0) = 0
+	`, SessionDrill).Scan(&f.EasyUnassisted); err != nil {
+		return f, fmt.Errorf("count unassisted sessions: %w", err)
+	}
+
+	status, err := db.GetStreak(c)
+	if err != nil {
+		return f, err
+	}
+	f.LongestStreak = status.Longest
+	return f, nil
+}
+
+// GetUnlockedAchievements returns when each unlocked achievement was
+// unlocked, by ID.
+func (db *DB) GetUnlockedAchievements() (map[string]string, error) {
+	rows, err := db.conn.Query(`SELECT id, unlocked_at FROM achievements`)
+	if err != nil {
+		return nil, fmt.Errorf("query achievements: %w", err)
+	}
+	defer rows.Close()
+	unlocked := map[string]string{}
+	for rows.Next() {
+		var id, at string
+		if err := rows.Scan(&id, &at); err != nil {
+			return nil, err
+		}
+		unlocked[id] = at
+	}
+	return unlocked, rows.Err()
+}
+
+// UnlockAchievements records the achievements the history now meets and
+// returns the ones that weren't unlocked before, crediting sessionID
+// (which may be empty).
+func (db *DB) UnlockAchievements(c streak.Clock, sessionID string) ([]achievements.Achievement, error) {
+	facts, err := db.GetAchievementFacts(c)
+	if err != nil {
+		return nil, err
+	}
+	unlocked, err := db.GetUnlockedAchievements()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(unlocked))
+	for id := range unlocked {
+		seen[id] = true
+	}
+	newly := achievements.Newly(facts, seen)
+	var session any
+	if sessionID != "" {
+		session = sessionID
+	}
+	for _, a := range newly {
+		if _, err := db.conn.Exec(`
+			INSERT OR IGNORE INTO achievements (id, session_id) VALUES (?, ?)
+		`, a.ID, session); err != nil {
+			return nil, fmt.Errorf("save achievement: %w", err)
+		}
+	}
+	return newly, nil
+}
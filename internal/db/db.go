@@ -9,6 +9,8 @@ import (
 
 	"github.com/google/uuid"
 	_ "modernc.org/sqlite"
+
+	"bonk/internal/streak"
 )
 
 const schema = `
@@ -44,23 +46,181 @@ CREATE TABLE IF NOT EXISTS scheduling (
   last_reviewed_at TEXT
 );
 
+CREATE TABLE IF NOT EXISTS api_usage (
+  id TEXT PRIMARY KEY,
+  session_id TEXT NOT NULL,
+  turn INTEGER NOT NULL,
+  kind TEXT NOT NULL,
+  model TEXT NOT NULL,
+  input_tokens INTEGER NOT NULL DEFAULT 0,
+  output_tokens INTEGER NOT NULL DEFAULT 0,
+  cache_write_tokens INTEGER NOT NULL DEFAULT 0,
+  cache_read_tokens INTEGER NOT NULL DEFAULT 0,
+  created_at TEXT NOT NULL DEFAULT (datetime('now')),
+  FOREIGN KEY(session_id) REFERENCES sessions(id)
+);
+
+-- Time, turns and coach score per phase of a system design practical interview
+CREATE TABLE IF NOT EXISTS session_phases (
+  session_id TEXT NOT NULL,
+  phase TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  turns INTEGER NOT NULL,
+  seconds REAL NOT NULL,
+  score INTEGER,
+  PRIMARY KEY(session_id, phase),
+  FOREIGN KEY(session_id) REFERENCES sessions(id)
+);
+
+-- Every question the coach has asked, near-identical ones merged
+CREATE TABLE IF NOT EXISTS questions (
+  id TEXT PRIMARY KEY,
+  skill_id TEXT NOT NULL,
+  facet TEXT,
+  problem TEXT,
+  summary TEXT NOT NULL,
+  times_asked INTEGER NOT NULL DEFAULT 1,
+  first_asked_at TEXT NOT NULL DEFAULT (datetime('now')),
+  last_asked_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Bookmarked exchanges with the user's notes; session_id and turn point
+-- at the exchange, excerpt keeps the coach's message
+CREATE TABLE IF NOT EXISTS notes (
+  id TEXT PRIMARY KEY,
+  skill_id TEXT NOT NULL,
+  session_id TEXT,
+  turn INTEGER,
+  excerpt TEXT NOT NULL,
+  note TEXT NOT NULL DEFAULT '',
+  created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Achievements unlocked, keyed by achievement ID, with the session that
+-- unlocked them (NULL when found on a later check)
+CREATE TABLE IF NOT EXISTS achievements (
+  id TEXT PRIMARY KEY,
+  session_id TEXT,
+  unlocked_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Study plans toward an interview date; only the latest is active
+CREATE TABLE IF NOT EXISTS plans (
+  id TEXT PRIMARY KEY,
+  until TEXT NOT NULL,
+  focus TEXT NOT NULL DEFAULT '',
+  minutes_per_day INTEGER NOT NULL,
+  active INTEGER NOT NULL DEFAULT 1,
+  created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- A plan's skills day by day; session_id is set when one is drilled
+CREATE TABLE IF NOT EXISTS plan_items (
+  plan_id TEXT NOT NULL,
+  day TEXT NOT NULL,
+  position INTEGER NOT NULL,
+  skill_id TEXT NOT NULL,
+  session_id TEXT,
+  PRIMARY KEY(plan_id, day, position),
+  FOREIGN KEY(plan_id) REFERENCES plans(id)
+);
+
 CREATE INDEX IF NOT EXISTS idx_scheduling_due ON scheduling(due_at);
 CREATE INDEX IF NOT EXISTS idx_exchanges_session ON exchanges(session_id);
 CREATE INDEX IF NOT EXISTS idx_sessions_skill ON sessions(skill_id);
+CREATE INDEX IF NOT EXISTS idx_api_usage_created ON api_usage(created_at);
+CREATE INDEX IF NOT EXISTS idx_questions_skill ON questions(skill_id);
+CREATE INDEX IF NOT EXISTS idx_notes_skill ON notes(skill_id);
 `
 
-type DB struct {
-	conn *sql.DB
+// columnMigrations adds columns introduced after the original schema. Each
+// column is only added when missing, so existing databases upgrade in place.
+var columnMigrations = []struct {
+	table, column, decl string
+}{
+	// Voice delivery metrics (NULL for typed answers)
+	{"exchanges", "speech_seconds", "REAL"},
+	{"exchanges", "words_per_minute", "REAL"},
+	{"exchanges", "filler_count", "INTEGER"},
+	{"exchanges", "hedge_count", "INTEGER"},
+	{"exchanges", "pause_ratio", "REAL"},
+	// Prompt template the coach ran with, e.g. drill@3f2a9c01b4de
+	{"sessions", "prompt_version", "TEXT"},
+	// The ratings blended into sessions.rating (NULL for older sessions)
+	{"sessions", "self_rating", "INTEGER"},
+	{"sessions", "coach_rating", "INTEGER"},
+	// Per-turn coach metadata; is_final marks the closing assessment,
+	// which has no answer
+	{"exchanges", "coach_rating", "INTEGER"},
+	{"exchanges", "phase", "TEXT"},
+	{"exchanges", "is_final", "INTEGER DEFAULT 0"},
+	// Design notebook kept during a system design practical, as JSON
+	{"sessions", "design_doc", "TEXT"},
+	// bonk's own check of the numbers in an estimation answer
+	{"exchanges", "estimate_check", "TEXT"},
+	// Hints taken before answering, and questions given up on
+	{"exchanges", "hints", "INTEGER DEFAULT 0"},
+	{"exchanges", "revealed", "INTEGER DEFAULT 0"},
+	{"sessions", "hints_used", "INTEGER DEFAULT 0"},
+	{"sessions", "reveals", "INTEGER DEFAULT 0"},
+	// How the session was run, see SessionDrill
+	{"sessions", "mode", "TEXT NOT NULL DEFAULT 'drill'"},
+}
+
+// columnBackfills fill in a column for existing rows, by table.column.
+// Each runs once, right after its column is added.
+var columnBackfills = map[string]string{
+	// Recall sessions from before the column are recognized by their cards
+	"sessions.mode": `UPDATE sessions SET mode = 'recall' WHERE id IN (SELECT session_id FROM exchanges WHERE question_type = 'recall')`,
+}
+
+func migrateColumns(conn *sql.DB) error {
+	for _, m := range columnMigrations {
+		exists, err := hasColumn(conn, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.decl)); err != nil {
+			return fmt.Errorf("add %s.%s: %w", m.table, m.column, err)
+		}
+		if backfill, ok := columnBackfills[m.table+"."+m.column]; ok {
+			if _, err := conn.Exec(backfill); err != nil {
+				return fmt.Errorf("backfill %s.%s: %w", m.table, m.column, err)
+			}
+		}
+	}
+	return nil
 }
 
-func dbPath() string {
-	home, _ := os.UserHomeDir()
-	return filepath.Join(home, ".bonk", "data.sqlite")
+func hasColumn(conn *sql.DB, table, column string) (bool, error) {
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, ctype string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &ctype, &notNull, &dflt, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
 }
 
-func Open() (*DB, error) {
-	path := dbPath()
+type DB struct {
+	conn *sql.DB
+}
 
+// Open opens (creating if needed) the SQLite database at path.
+func Open(path string) (*DB, error) {
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return nil, fmt.Errorf("create db dir: %w", err)
@@ -83,6 +243,11 @@ func Open() (*DB, error) {
 		return nil, fmt.Errorf("create schema: %w", err)
 	}
 
+	if err := migrateColumns(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("migrate schema: %w", err)
+	}
+
 	return &DB{conn: conn}, nil
 }
 
@@ -92,11 +257,28 @@ func (db *DB) Close() error {
 
 // Session management
 
-func (db *DB) CreateSession(skillID string) (string, error) {
+// Session modes
+const (
+	SessionDrill  = "drill"  // a coached drill
+	SessionRecall = "recall" // offline recall cards, self-graded
+)
+
+// CreateSession starts a coached drill, recording the version of the
+// prompt template its coach was given.
+func (db *DB) CreateSession(skillID, promptVersion string) (string, error) {
+	return db.createSession(skillID, SessionDrill, promptVersion)
+}
+
+// CreateRecallSession starts an offline recall session.
+func (db *DB) CreateRecallSession(skillID string) (string, error) {
+	return db.createSession(skillID, SessionRecall, "")
+}
+
+func (db *DB) createSession(skillID, mode, promptVersion string) (string, error) {
 	id := uuid.New().String()
 	_, err := db.conn.Exec(
-		"INSERT INTO sessions (id, skill_id) VALUES (?, ?)",
-		id, skillID,
+		"INSERT INTO sessions (id, skill_id, mode, prompt_version) VALUES (?, ?, ?, ?)",
+		id, skillID, mode, sql.NullString{String: promptVersion, Valid: promptVersion != ""},
 	)
 	if err != nil {
 		return "", fmt.Errorf("create session: %w", err)
@@ -104,7 +286,21 @@ func (db *DB) CreateSession(skillID string) (string, error) {
 	return id, nil
 }
 
-func (db *DB) FinishSession(sessionID string, rating int, assessment string) error {
+// SaveDesignDoc stores a session's design notebook, replacing any saved
+// before.
+func (db *DB) SaveDesignDoc(sessionID, doc string) error {
+	_, err := db.conn.Exec("UPDATE sessions SET design_doc = ? WHERE id = ?", doc, sessionID)
+	if err != nil {
+		return fmt.Errorf("save design doc: %w", err)
+	}
+	return nil
+}
+
+// FinishSession stores a session's ratings and assessment and reschedules
+// its skill by the final rating. c decides where an active study plan's
+// last day ends.
+func (db *DB) FinishSession(c streak.Clock, sessionID string, ratings Ratings, assessment string) error {
+	rating := ratings.Final
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
@@ -113,8 +309,11 @@ func (db *DB) FinishSession(sessionID string, rating int, assessment string) err
 
 	// Update session
 	_, err = tx.Exec(
-		"UPDATE sessions SET finished_at = datetime('now'), rating = ?, assessment = ? WHERE id = ?",
-		rating, assessment, sessionID,
+		`UPDATE sessions SET finished_at = datetime('now'), rating = ?, self_rating = ?, coach_rating = ?, assessment = ?,
+			hints_used = ?, reveals = ?
+		WHERE id = ?`,
+		rating, ratings.Self, sql.NullInt64{Int64: int64(ratings.Coach), Valid: ratings.Coach > 0}, assessment,
+		ratings.Help.Hints, ratings.Help.Reveals, sessionID,
 	)
 	if err != nil {
 		return fmt.Errorf("update session: %w", err)
@@ -174,6 +373,8 @@ func (db *DB) FinishSession(sessionID string, rating int, assessment string) err
 			stability = 1
 		}
 		stability = stability * difficulty
+		// Leaning on hints brings the next review closer
+		stability = max(1, stability*ratings.Help.IntervalFactor())
 		intervalDays = stability
 	}
 
@@ -181,6 +382,10 @@ func (db *DB) FinishSession(sessionID string, rating int, assessment string) err
 	if intervalDays > 365 {
 		intervalDays = 365
 	}
+	// With a study plan active, bring the review in before the interview
+	if left, ok := planDaysLeft(tx, c, time.Now()); ok && intervalDays > left {
+		intervalDays = left
+	}
 
 	// Update scheduling
 	_, err = tx.Exec(`
@@ -198,6 +403,9 @@ func (db *DB) FinishSession(sessionID string, rating int, assessment string) err
 	if err != nil {
 		return fmt.Errorf("update scheduling: %w", err)
 	}
+	if err := completePlanItem(tx, skillID, sessionID); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -205,29 +413,58 @@ func (db *DB) FinishSession(sessionID string, rating int, assessment string) err
 // Exchange management
 
 type Exchange struct {
-	Turn     int
-	Question string
-	Answer   string
-	Facet    string
+	Turn         int
+	Question     string
+	QuestionType string
+	Facet        string
+	Answer       string
+	Struggled    bool
+	Delivery     *Delivery // nil for typed answers
+	CoachRating  int       // the coach's running rating with this question, 0 if none
+	Phase        string    // system-design-practical interview phase
+	Final        bool      // the closing assessment; Answer is empty
+	Check        string    // estimation drills: how the answer's number compared to the reference
+	Hints        int       // hints taken before answering
+	Revealed     bool      // gave up and had the coach give the answer
+}
+
+// Delivery holds voice delivery metrics for a spoken answer.
+type Delivery struct {
+	SpeechSeconds  float64
+	WordsPerMinute float64
+	Fillers        int
+	Hedges         int
+	PauseRatio     float64
+}
+
+// Timed reports whether the recording could be timed. Without timing only
+// the word counts mean anything, and the rate and pauses are stored NULL.
+func (d *Delivery) Timed() bool {
+	return d.SpeechSeconds > 0
 }
 
 type SessionDetail struct {
-	ID         string
-	SkillID    string
-	StartedAt  string
-	FinishedAt string
-	Rating     int
-	Assessment string
-	Exchanges  []Exchange
+	ID            string
+	SkillID       string
+	StartedAt     string
+	FinishedAt    string
+	Rating        int
+	SelfRating    int // 0 for sessions from before it was recorded
+	CoachRating   int // 0 if the coach gave none
+	Assessment    string
+	PromptVersion string // empty for sessions from before it was recorded
+	DesignDoc     string // notebook JSON, empty outside system design practicals
+	Exchanges     []Exchange
 }
 
 func (db *DB) GetLastSession(skillID string) (*SessionDetail, error) {
 	var s SessionDetail
-	var finishedAt, assessment sql.NullString
-	var rating sql.NullInt64
+	var finishedAt, assessment, promptVersion, designDoc sql.NullString
+	var rating, selfRating, coachRating sql.NullInt64
 
 	query := `
-		SELECT id, skill_id, started_at, finished_at, rating, assessment
+		SELECT id, skill_id, started_at, finished_at, rating, self_rating, coach_rating, assessment, prompt_version,
+			design_doc
 		FROM sessions
 		WHERE finished_at IS NOT NULL
 	`
@@ -239,7 +476,8 @@ func (db *DB) GetLastSession(skillID string) (*SessionDetail, error) {
 	query += " ORDER BY finished_at DESC LIMIT 1"
 
 	err := db.conn.QueryRow(query, args...).Scan(
-		&s.ID, &s.SkillID, &s.StartedAt, &finishedAt, &rating, &assessment,
+		&s.ID, &s.SkillID, &s.StartedAt, &finishedAt, &rating, &selfRating, &coachRating, &assessment, &promptVersion,
+		&designDoc,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -257,10 +495,16 @@ func (db *DB) GetLastSession(skillID string) (*SessionDetail, error) {
 	if assessment.Valid {
 		s.Assessment = assessment.String
 	}
+	s.SelfRating = int(selfRating.Int64)
+	s.CoachRating = int(coachRating.Int64)
+	s.PromptVersion = promptVersion.String
+	s.DesignDoc = designDoc.String
 
 	// Get exchanges
 	rows, err := db.conn.Query(`
-		SELECT turn, question, answer, facet
+		SELECT turn, question, answer, facet, question_type, struggled,
+			speech_seconds, words_per_minute, filler_count, hedge_count, pause_ratio,
+			coach_rating, phase, is_final, estimate_check, hints, revealed
 		FROM exchanges
 		WHERE session_id = ?
 		ORDER BY turn ASC
@@ -272,12 +516,32 @@ func (db *DB) GetLastSession(skillID string) (*SessionDetail, error) {
 
 	for rows.Next() {
 		var e Exchange
-		var facet sql.NullString
-		if err := rows.Scan(&e.Turn, &e.Question, &e.Answer, &facet); err != nil {
+		var facet, questionType, phase, check sql.NullString
+		var struggled int
+		var speechSeconds, wpm, pauseRatio sql.NullFloat64
+		var fillers, hedges, coachRating, final, hints, revealed sql.NullInt64
+		if err := rows.Scan(&e.Turn, &e.Question, &e.Answer, &facet, &questionType, &struggled,
+			&speechSeconds, &wpm, &fillers, &hedges, &pauseRatio,
+			&coachRating, &phase, &final, &check, &hints, &revealed); err != nil {
 			return nil, err
 		}
-		if facet.Valid {
-			e.Facet = facet.String
+		e.Facet = facet.String
+		e.QuestionType = questionType.String
+		e.Struggled = struggled == 1
+		e.CoachRating = int(coachRating.Int64)
+		e.Phase = phase.String
+		e.Check = check.String
+		e.Final = final.Int64 == 1
+		e.Hints = int(hints.Int64)
+		e.Revealed = revealed.Int64 == 1
+		if fillers.Valid {
+			e.Delivery = &Delivery{
+				SpeechSeconds:  speechSeconds.Float64,
+				WordsPerMinute: wpm.Float64,
+				Fillers:        int(fillers.Int64),
+				Hedges:         int(hedges.Int64),
+				PauseRatio:     pauseRatio.Float64,
+			}
 		}
 		s.Exchanges = append(s.Exchanges, e)
 	}
@@ -285,16 +549,43 @@ func (db *DB) GetLastSession(skillID string) (*SessionDetail, error) {
 	return &s, rows.Err()
 }
 
-func (db *DB) SaveExchange(sessionID string, turn int, question, questionType, facet, answer string, struggled bool) error {
+func (db *DB) SaveExchange(sessionID string, e Exchange) error {
 	id := uuid.New().String()
 	struggledInt := 0
-	if struggled {
+	if e.Struggled {
 		struggledInt = 1
 	}
+	finalInt := 0
+	if e.Final {
+		finalInt = 1
+	}
+	revealedInt := 0
+	if e.Revealed {
+		revealedInt = 1
+	}
 
-	_, err := db.conn.Exec(
-		"INSERT INTO exchanges (id, session_id, turn, question, question_type, facet, answer, struggled) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
-		id, sessionID, turn, question, questionType, facet, answer, struggledInt,
+	var speechSeconds, wpm, pauseRatio sql.NullFloat64
+	var fillers, hedges sql.NullInt64
+	if d := e.Delivery; d != nil {
+		fillers = sql.NullInt64{Int64: int64(d.Fillers), Valid: true}
+		hedges = sql.NullInt64{Int64: int64(d.Hedges), Valid: true}
+		if d.Timed() {
+			speechSeconds = sql.NullFloat64{Float64: d.SpeechSeconds, Valid: true}
+			wpm = sql.NullFloat64{Float64: d.WordsPerMinute, Valid: true}
+			pauseRatio = sql.NullFloat64{Float64: d.PauseRatio, Valid: true}
+		}
+	}
+
+	_, err := db.conn.Exec(`
+		INSERT INTO exchanges (id, session_id, turn, question, question_type, facet, answer, struggled,
+			speech_seconds, words_per_minute, filler_count, hedge_count, pause_ratio,
+			coach_rating, phase, is_final, estimate_check, hints, revealed)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, sessionID, e.Turn, e.Question, e.QuestionType, e.Facet, e.Answer, struggledInt,
+		speechSeconds, wpm, fillers, hedges, pauseRatio,
+		sql.NullInt64{Int64: int64(e.CoachRating), Valid: e.CoachRating > 0},
+		sql.NullString{String: e.Phase, Valid: e.Phase != ""}, finalInt,
+		sql.NullString{String: e.Check, Valid: e.Check != ""}, e.Hints, revealedInt,
 	)
 	if err != nil {
 		return fmt.Errorf("save exchange: %w", err)
@@ -355,7 +646,7 @@ func (db *DB) GetWeakFacets(limit int) ([]FacetStats, error) {
 	rows, err := db.conn.Query(`
 		SELECT facet, COUNT(*) as total, SUM(struggled) as struggled
 		FROM exchanges
-		WHERE facet IS NOT NULL AND facet != ''
+		WHERE facet IS NOT NULL AND facet != '' AND is_final = 0
 		GROUP BY facet
 		HAVING total >= 3
 		ORDER BY CAST(struggled AS FLOAT) / total DESC
@@ -485,7 +776,7 @@ func (db *DB) GetHistoryContext(skillID string, limit int) (string, error) {
 		SELECT e.facet, e.struggled
 		FROM exchanges e
 		JOIN sessions s ON s.id = e.session_id
-		WHERE s.skill_id = ? AND e.facet IS NOT NULL AND e.facet != ''
+		WHERE s.skill_id = ? AND e.facet IS NOT NULL AND e.facet != '' AND e.is_final = 0
 		ORDER BY e.created_at DESC
 		LIMIT ?
 	`, skillID, limit*3)
@@ -547,89 +838,6 @@ func (db *DB) GetHistoryContext(skillID string, limit int) (string, error) {
 	return result, nil
 }
 
-// Streak calculates consecutive days with at least one completed session
-func (db *DB) GetStreak() (current int, longest int, err error) {
-	rows, err := db.conn.Query(`
-		SELECT DISTINCT date(finished_at) as d
-		FROM sessions
-		WHERE finished_at IS NOT NULL
-		ORDER BY d DESC
-	`)
-	if err != nil {
-		return 0, 0, err
-	}
-	defer rows.Close()
-
-	var dates []string
-	for rows.Next() {
-		var d string
-		if err := rows.Scan(&d); err != nil {
-			return 0, 0, err
-		}
-		dates = append(dates, d)
-	}
-
-	if len(dates) == 0 {
-		return 0, 0, nil
-	}
-
-	// Calculate current streak (must include today or yesterday)
-	current = 1
-	for i := 1; i < len(dates); i++ {
-		if isConsecutiveDay(dates[i-1], dates[i]) {
-			current++
-		} else {
-			break
-		}
-	}
-
-	// Check if streak is still active (last drill was today or yesterday)
-	if !isRecentDay(dates[0]) {
-		current = 0
-	}
-
-	// Calculate longest streak
-	longest = 1
-	streak := 1
-	for i := 1; i < len(dates); i++ {
-		if isConsecutiveDay(dates[i-1], dates[i]) {
-			streak++
-			if streak > longest {
-				longest = streak
-			}
-		} else {
-			streak = 1
-		}
-	}
-
-	return current, longest, nil
-}
-
-func parseDate(s string) (time.Time, error) {
-	// SQLite date format: YYYY-MM-DD
-	return time.Parse("2006-01-02", s)
-}
-
-func isConsecutiveDay(newer, older string) bool {
-	n, err1 := parseDate(newer)
-	o, err2 := parseDate(older)
-	if err1 != nil || err2 != nil {
-		return false
-	}
-	diff := n.Sub(o).Hours() / 24
-	return diff == 1
-}
-
-func isRecentDay(date string) bool {
-	d, err := parseDate(date)
-	if err != nil {
-		return false
-	}
-	today := time.Now().Truncate(24 * time.Hour)
-	diff := today.Sub(d).Hours() / 24
-	return diff <= 1
-}
-
 // DomainStats holds aggregate stats for a domain
 type DomainStats struct {
 	Domain       string
@@ -770,13 +978,3 @@ func (db *DB) GetRecentRatings(limit int) ([]int, error) {
 	}
 	return ratings, rows.Err()
 }
-
-// GetTodaySessionCount returns sessions completed today
-func (db *DB) GetTodaySessionCount() (int, error) {
-	var count int
-	err := db.conn.QueryRow(`
-		SELECT COUNT(*) FROM sessions
-		WHERE date(finished_at) = date('now') AND finished_at IS NOT NULL
-	`).Scan(&count)
-	return count, err
-}
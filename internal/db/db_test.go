@@ -0,0 +1,158 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bonk/internal/streak"
+)
+
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	database, err := Open(filepath.Join(t.TempDir(), "bonk.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return database
+}
+
+func TestFinalExchangeRoundTrip(t *testing.T) {
+	database := openTestDB(t)
+	id, err := database.CreateSession("caching", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []Exchange{
+		{Turn: 1, Question: "What do you cache?", Answer: "Hot reads.", Facet: "strategy", CoachRating: 2, Phase: "requirements"},
+		{Turn: 2, Question: "Solid overall.", CoachRating: 3, Phase: "wrap-up", Final: true},
+	} {
+		if err := database.SaveExchange(id, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.FinishSession(streak.Clock{}, id, Ratings{Self: 3, Coach: 3, Final: 3}, "Solid overall."); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := database.GetLastSession("caching")
+	if err != nil || s == nil {
+		t.Fatalf("GetLastSession = %v, %v", s, err)
+	}
+	if len(s.Exchanges) != 2 {
+		t.Fatalf("exchanges = %+v", s.Exchanges)
+	}
+	first, final := s.Exchanges[0], s.Exchanges[1]
+	if first.Final || first.CoachRating != 2 || first.Phase != "requirements" {
+		t.Errorf("first exchange = %+v", first)
+	}
+	if !final.Final || final.CoachRating != 3 || final.Phase != "wrap-up" || final.Answer != "" {
+		t.Errorf("final exchange = %+v", final)
+	}
+	if s.CoachRating != 3 || s.Assessment != "Solid overall." {
+		t.Errorf("session = %+v", s)
+	}
+}
+
+func TestUntimedDeliveryKeepsAveragesHonest(t *testing.T) {
+	database := openTestDB(t)
+	id, err := database.CreateSession("caching", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range []*Delivery{
+		{SpeechSeconds: 30, WordsPerMinute: 150, Fillers: 2, PauseRatio: 0.2},
+		{Fillers: 4}, // the recording couldn't be read
+	} {
+		if err := database.SaveExchange(id, Exchange{Turn: 1, Question: "Why?", Answer: "Because.", Delivery: d}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.FinishSession(streak.Clock{}, id, Ratings{Self: 3, Final: 3}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := database.GetLastSession("caching")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := s.Exchanges[1].Delivery; d == nil || d.Timed() || d.Fillers != 4 {
+		t.Fatalf("untimed delivery read back as %+v", d)
+	}
+	sum := SummarizeDelivery(s.Exchanges)
+	if sum.Answers != 2 || sum.WordsPerMinute != 150 || sum.FillersPerAnswer != 3 {
+		t.Errorf("summary = %+v, want 150 wpm over the timed answer and 3 fillers per answer", sum)
+	}
+	trend, err := database.GetDeliveryTrend(5)
+	if err != nil || len(trend) != 1 || trend[0].WordsPerMinute != 150 {
+		t.Errorf("trend = %+v, %v", trend, err)
+	}
+}
+
+func TestRecallSessionsDontEarnDrillAchievements(t *testing.T) {
+	database := openTestDB(t)
+	for range 12 {
+		id, err := database.CreateRecallSession("design-uber")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := database.FinishSession(streak.Clock{}, id, Ratings{Self: 4, Final: 4}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := database.UnlockAchievements(streak.Clock{}, ""); err != nil {
+		t.Fatal(err)
+	}
+	unlocked, err := database.GetUnlockedAchievements()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"first-drill", "first-interview", "unassisted-10", "domain-system-design-practical"} {
+		if _, ok := unlocked[id]; ok {
+			t.Errorf("recall sessions unlocked %s", id)
+		}
+	}
+
+	id, err := database.CreateSession("design-uber", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.FinishSession(streak.Clock{}, id, Ratings{Self: 3, Final: 3}, ""); err != nil {
+		t.Fatal(err)
+	}
+	newly, err := database.UnlockAchievements(streak.Clock{}, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, a := range newly {
+		got[a.ID] = true
+	}
+	if !got["first-drill"] || !got["first-interview"] {
+		t.Errorf("a coached practical unlocked %v", got)
+	}
+}
+
+func TestUsageDaysFollowRollover(t *testing.T) {
+	database := openTestDB(t)
+	id, err := database.CreateSession("design-uber", "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.RecordUsage(UsageRecord{SessionID: id, Turn: 1, Kind: UsageDrill, Model: "m", Tokens: TokenUsage{Input: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	// 01:30 UTC is still the previous practice day with a 4am rollover.
+	if _, err := database.conn.Exec(`UPDATE api_usage SET created_at = '2026-03-10 01:30:00'`); err != nil {
+		t.Fatal(err)
+	}
+	c := streak.Clock{Loc: time.UTC, RolloverHour: 4}
+	rows, err := database.GetUsage(c, time.Date(2026, 3, 9, 4, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Day != "2026-03-09" || rows[0].Calls != 1 || rows[0].Tokens.Input != 10 {
+		t.Errorf("GetUsage = %+v, want one call on 2026-03-09", rows)
+	}
+}
@@ -0,0 +1,79 @@
+package db
+
+// DeliverySummary averages voice delivery metrics over a session's spoken answers.
+type DeliverySummary struct {
+	SessionID        string
+	SkillID          string
+	FinishedAt       string
+	Answers          int
+	WordsPerMinute   float64
+	FillersPerAnswer float64
+	HedgesPerAnswer  float64
+	PauseRatio       float64
+}
+
+// SummarizeDelivery averages the delivery metrics of spoken exchanges. The
+// rate and pauses are averaged over the answers that could be timed.
+// Returns nil when no answer in the session was spoken.
+func SummarizeDelivery(exchanges []Exchange) *DeliverySummary {
+	var s DeliverySummary
+	timed := 0
+	for _, e := range exchanges {
+		if e.Delivery == nil {
+			continue
+		}
+		s.Answers++
+		s.FillersPerAnswer += float64(e.Delivery.Fillers)
+		s.HedgesPerAnswer += float64(e.Delivery.Hedges)
+		if e.Delivery.Timed() {
+			timed++
+			s.WordsPerMinute += e.Delivery.WordsPerMinute
+			s.PauseRatio += e.Delivery.PauseRatio
+		}
+	}
+	if s.Answers == 0 {
+		return nil
+	}
+	n := float64(s.Answers)
+	s.FillersPerAnswer /= n
+	s.HedgesPerAnswer /= n
+	if timed > 0 {
+		s.WordsPerMinute /= float64(timed)
+		s.PauseRatio /= float64(timed)
+	}
+	return &s
+}
+
+// GetDeliveryTrend returns per-session delivery averages for the most recent
+// finished sessions that had spoken answers, oldest first.
+func (db *DB) GetDeliveryTrend(limit int) ([]DeliverySummary, error) {
+	rows, err := db.conn.Query(`
+		SELECT s.id, s.skill_id, s.finished_at, COUNT(*),
+			AVG(e.words_per_minute), AVG(e.filler_count), AVG(e.hedge_count), AVG(e.pause_ratio)
+		FROM exchanges e
+		JOIN sessions s ON s.id = e.session_id
+		WHERE s.finished_at IS NOT NULL AND e.words_per_minute IS NOT NULL
+		GROUP BY s.id
+		ORDER BY s.finished_at DESC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trend []DeliverySummary
+	for rows.Next() {
+		var d DeliverySummary
+		if err := rows.Scan(&d.SessionID, &d.SkillID, &d.FinishedAt, &d.Answers,
+			&d.WordsPerMinute, &d.FillersPerAnswer, &d.HedgesPerAnswer, &d.PauseRatio); err != nil {
+			return nil, err
+		}
+		trend = append(trend, d)
+	}
+	// Reverse to oldest first
+	for i, j := 0, len(trend)-1; i < j; i, j = i+1, j-1 {
+		trend[i], trend[j] = trend[j], trend[i]
+	}
+	return trend, rows.Err()
+}
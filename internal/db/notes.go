@@ -0,0 +1,53 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Note is a bookmarked exchange, with what the user wrote about it.
+type Note struct {
+	SkillID   string
+	SessionID string
+	Turn      int    // the bookmarked exchange's turn in the session
+	Excerpt   string // the coach's message
+	Text      string // empty for a plain bookmark
+	CreatedAt string
+}
+
+// SaveNote stores a bookmark.
+func (db *DB) SaveNote(n Note) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO notes (id, skill_id, session_id, turn, excerpt, note) VALUES (?, ?, ?, ?, ?, ?)
+	`, uuid.New().String(), n.SkillID, n.SessionID, n.Turn, n.Excerpt, n.Text)
+	if err != nil {
+		return fmt.Errorf("save note: %w", err)
+	}
+	return nil
+}
+
+// GetNotes returns the notes for a skill, or for every skill when skillID
+// is empty, grouped by skill and oldest first.
+func (db *DB) GetNotes(skillID string) ([]Note, error) {
+	rows, err := db.conn.Query(`
+		SELECT skill_id, COALESCE(session_id, ''), COALESCE(turn, 0), excerpt, note, created_at
+		FROM notes
+		WHERE ? = '' OR skill_id = ?
+		ORDER BY skill_id, created_at, rowid
+	`, skillID, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("query notes: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Note
+	for rows.Next() {
+		var n Note
+		if err := rows.Scan(&n.SkillID, &n.SessionID, &n.Turn, &n.Excerpt, &n.Text, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
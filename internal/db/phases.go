@@ -0,0 +1,65 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// PhaseStat is the time and turns a session spent in one interview phase.
+type PhaseStat struct {
+	Phase   string
+	Turns   int
+	Seconds float64
+	Score   int // the coach's 1-4 score, 0 if none
+}
+
+// SavePhaseStats stores a session's phases in the order they were entered,
+// replacing any saved before.
+func (db *DB) SavePhaseStats(sessionID string, stats []PhaseStat) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM session_phases WHERE session_id = ?", sessionID); err != nil {
+		return fmt.Errorf("clear phases: %w", err)
+	}
+	for i, s := range stats {
+		_, err := tx.Exec(`
+			INSERT INTO session_phases (session_id, phase, position, turns, seconds, score)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			sessionID, s.Phase, i, s.Turns, s.Seconds, sql.NullInt64{Int64: int64(s.Score), Valid: s.Score > 0},
+		)
+		if err != nil {
+			return fmt.Errorf("save phase %s: %w", s.Phase, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// GetPhaseStats returns a session's phases in the order they were entered.
+func (db *DB) GetPhaseStats(sessionID string) ([]PhaseStat, error) {
+	rows, err := db.conn.Query(`
+		SELECT phase, turns, seconds, score
+		FROM session_phases
+		WHERE session_id = ?
+		ORDER BY position
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []PhaseStat
+	for rows.Next() {
+		var s PhaseStat
+		var score sql.NullInt64
+		if err := rows.Scan(&s.Phase, &s.Turns, &s.Seconds, &score); err != nil {
+			return nil, err
+		}
+		s.Score = int(score.Int64)
+		result = append(result, s)
+	}
+	return result, rows.Err()
+}
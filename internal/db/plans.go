@@ -0,0 +1,199 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"bonk/internal/streak"
+)
+
+// Plan is a study plan toward an interview date.
+type Plan struct {
+	ID            string
+	Until         string   // YYYY-MM-DD, the last study day
+	Focus         []string // domains; empty means all
+	MinutesPerDay int
+	Days          []PlanDay
+}
+
+// PlanDay is one day of a plan.
+type PlanDay struct {
+	Date  string // YYYY-MM-DD
+	Items []PlanItem
+}
+
+// PlanItem is a skill planned for a day.
+type PlanItem struct {
+	SkillID string
+	Done    bool // drilled, on this day or another
+}
+
+// SavePlan stores p as the active plan, replacing any earlier one, and
+// returns its ID.
+func (db *DB) SavePlan(p Plan) (string, error) {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return "", fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE plans SET active = 0 WHERE active = 1`); err != nil {
+		return "", fmt.Errorf("retire plans: %w", err)
+	}
+	id := uuid.New().String()
+	if _, err := tx.Exec(`
+		INSERT INTO plans (id, until, focus, minutes_per_day) VALUES (?, ?, ?, ?)
+	`, id, p.Until, strings.Join(p.Focus, ","), p.MinutesPerDay); err != nil {
+		return "", fmt.Errorf("save plan: %w", err)
+	}
+	for _, day := range p.Days {
+		for i, item := range day.Items {
+			if _, err := tx.Exec(`
+				INSERT INTO plan_items (plan_id, day, position, skill_id) VALUES (?, ?, ?, ?)
+			`, id, day.Date, i, item.SkillID); err != nil {
+				return "", fmt.Errorf("save plan item: %w", err)
+			}
+		}
+	}
+	return id, tx.Commit()
+}
+
+// GetActivePlan returns the active plan, or nil when there is none or its
+// date has passed.
+func (db *DB) GetActivePlan(today string) (*Plan, error) {
+	p := &Plan{}
+	var focus string
+	err := db.conn.QueryRow(`
+		SELECT id, until, focus, minutes_per_day FROM plans
+		WHERE active = 1 AND until >= ?
+	`, today).Scan(&p.ID, &p.Until, &focus, &p.MinutesPerDay)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get plan: %w", err)
+	}
+	if focus != "" {
+		p.Focus = strings.Split(focus, ",")
+	}
+
+	rows, err := db.conn.Query(`
+		SELECT day, skill_id, session_id IS NOT NULL FROM plan_items
+		WHERE plan_id = ?
+		ORDER BY day, position
+	`, p.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get plan items: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var day string
+		var item PlanItem
+		if err := rows.Scan(&day, &item.SkillID, &item.Done); err != nil {
+			return nil, err
+		}
+		if n := len(p.Days); n == 0 || p.Days[n-1].Date != day {
+			p.Days = append(p.Days, PlanDay{Date: day})
+		}
+		last := &p.Days[len(p.Days)-1]
+		last.Items = append(last.Items, item)
+	}
+	return p, rows.Err()
+}
+
+// ClearPlan retires the active plan. It reports whether there was one.
+func (db *DB) ClearPlan() (bool, error) {
+	res, err := db.conn.Exec(`UPDATE plans SET active = 0 WHERE active = 1`)
+	if err != nil {
+		return false, fmt.Errorf("clear plan: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// GetPendingPlanSkills returns the active plan's skills not yet drilled
+// that were planned for today or earlier, in plan order.
+func (db *DB) GetPendingPlanSkills(today string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT i.skill_id FROM plan_items i
+		JOIN plans p ON p.id = i.plan_id
+		WHERE p.active = 1 AND p.until >= ? AND i.day <= ? AND i.session_id IS NULL
+		ORDER BY i.day, i.position
+	`, today, today)
+	if err != nil {
+		return nil, fmt.Errorf("get pending plan items: %w", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetAllScheduling returns every skill's scheduling, by skill ID.
+func (db *DB) GetAllScheduling() (map[string]SchedulingInfo, error) {
+	rows, err := db.conn.Query(`
+		SELECT skill_id, due_at, stability, difficulty, lapses FROM scheduling
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("get scheduling: %w", err)
+	}
+	defer rows.Close()
+	all := map[string]SchedulingInfo{}
+	for rows.Next() {
+		var s SchedulingInfo
+		if err := rows.Scan(&s.SkillID, &s.DueAt, &s.Stability, &s.Difficulty, &s.Lapses); err != nil {
+			return nil, err
+		}
+		all[s.SkillID] = s
+	}
+	return all, rows.Err()
+}
+
+// completePlanItem ticks off the earliest undone item for the skill in the
+// active plan, crediting the session.
+func completePlanItem(tx *sql.Tx, skillID, sessionID string) error {
+	_, err := tx.Exec(`
+		UPDATE plan_items SET session_id = ?
+		WHERE rowid = (
+			SELECT i.rowid FROM plan_items i
+			JOIN plans p ON p.id = i.plan_id
+			WHERE p.active = 1 AND i.skill_id = ? AND i.session_id IS NULL
+			ORDER BY i.day, i.position
+			LIMIT 1
+		)
+	`, sessionID, skillID)
+	if err != nil {
+		return fmt.Errorf("complete plan item: %w", err)
+	}
+	return nil
+}
+
+// planDaysLeft returns the whole days from now to the end of the active
+// plan's last day on c, the clock the plan was laid out by, so a review
+// scheduled that far out still lands before the interview. ok is false
+// without an active plan.
+func planDaysLeft(tx *sql.Tx, c streak.Clock, now time.Time) (days float64, ok bool) {
+	var until string
+	if err := tx.QueryRow(`SELECT until FROM plans WHERE active = 1`).Scan(&until); err != nil {
+		return 0, false
+	}
+	end, err := c.EndOfDay(until)
+	if err != nil {
+		return 0, false
+	}
+	left := float64(int(end.Sub(now).Hours() / 24))
+	if left < 1 {
+		return 0, false
+	}
+	return left, true
+}
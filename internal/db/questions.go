@@ -0,0 +1,122 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"bonk/internal/questions"
+)
+
+// Question is a coach question for the question bank.
+type Question struct {
+	Facet   string
+	Problem string // named problem the question is built on, if any
+	Text    string // the coach's message
+}
+
+// AskedQuestion is a question bank entry.
+type AskedQuestion struct {
+	Facet      string
+	Problem    string
+	Summary    string
+	TimesAsked int
+	LastAsked  string
+}
+
+// RecordQuestion adds a question to the skill's bank. A question
+// near-identical to one already there is counted against that entry
+// instead of added again.
+func (db *DB) RecordQuestion(skillID string, q Question) error {
+	summary := questions.Summary(q.Text)
+	if summary == "" {
+		return nil
+	}
+
+	rows, err := db.conn.Query(`SELECT id, summary FROM questions WHERE skill_id = ?`, skillID)
+	if err != nil {
+		return fmt.Errorf("query questions: %w", err)
+	}
+	var match string
+	for rows.Next() {
+		var id, s string
+		if err := rows.Scan(&id, &s); err != nil {
+			rows.Close()
+			return err
+		}
+		if questions.Similar(summary, s) {
+			match = id
+			break
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if match != "" {
+		_, err = db.conn.Exec(`
+			UPDATE questions SET times_asked = times_asked + 1, last_asked_at = datetime('now'),
+				problem = COALESCE(NULLIF(problem, ''), ?)
+			WHERE id = ?
+		`, q.Problem, match)
+	} else {
+		_, err = db.conn.Exec(`
+			INSERT INTO questions (id, skill_id, facet, problem, summary) VALUES (?, ?, ?, ?, ?)
+		`, uuid.New().String(), skillID, q.Facet, q.Problem, summary)
+	}
+	if err != nil {
+		return fmt.Errorf("record question: %w", err)
+	}
+	return nil
+}
+
+// GetAskedQuestions returns the skill's question bank, most recently asked
+// first.
+func (db *DB) GetAskedQuestions(skillID string, limit int) ([]AskedQuestion, error) {
+	rows, err := db.conn.Query(`
+		SELECT COALESCE(facet, ''), COALESCE(problem, ''), summary, times_asked, last_asked_at
+		FROM questions
+		WHERE skill_id = ?
+		ORDER BY last_asked_at DESC, rowid DESC
+		LIMIT ?
+	`, skillID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query questions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []AskedQuestion
+	for rows.Next() {
+		var q AskedQuestion
+		if err := rows.Scan(&q.Facet, &q.Problem, &q.Summary, &q.TimesAsked, &q.LastAsked); err != nil {
+			return nil, err
+		}
+		out = append(out, q)
+	}
+	return out, rows.Err()
+}
+
+// GetUsedProblems returns every named problem the coach has used for a
+// skill.
+func (db *DB) GetUsedProblems(skillID string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT DISTINCT problem FROM questions
+		WHERE skill_id = ? AND problem IS NOT NULL AND problem != ''
+		ORDER BY problem
+	`, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("query problems: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"math"
+	"time"
+)
+
+// Ratings of a finished session.
+type Ratings struct {
+	Self  int // the user's 1-4 key on the rating screen
+	Coach int // the coach's final rating, 0 if it gave none
+	Final int // what the skill is scheduled by, see RatingBlend
+	Help  Help
+}
+
+// Help is what the user leaned on during a session.
+type Help struct {
+	Hints   int // hints taken, over all questions
+	Reveals int // questions given up on
+}
+
+// Cap keeps a session that needed help from being rated Easy: a revealed
+// answer or two or more hints make a 4 a 3.
+func (h Help) Cap(rating int) int {
+	if rating > 3 && (h.Reveals > 0 || h.Hints >= 2) {
+		return 3
+	}
+	return rating
+}
+
+// IntervalFactor scales the next review interval for the help taken: each
+// hint takes 10% off and each revealed answer 25%, down to half.
+func (h Help) IntervalFactor() float64 {
+	return max(0.5, 1-0.1*float64(h.Hints)-0.25*float64(h.Reveals))
+}
+
+// Rating blend modes
+const (
+	BlendCoach    = "coach"    // the coach's rating
+	BlendSelf     = "self"     // the user's own rating
+	BlendWeighted = "weighted" // a weighted average of both
+)
+
+// RatingBlend decides which rating a session is scheduled by.
+type RatingBlend struct {
+	Mode       string
+	SelfWeight int // percent weight of the self rating in weighted mode
+}
+
+// DefaultBlend averages both ratings, rounding up.
+var DefaultBlend = RatingBlend{Mode: BlendWeighted, SelfWeight: 50}
+
+// Combine returns the final rating. Without a coach rating the self
+// rating is used in every mode.
+func (b RatingBlend) Combine(self, coach int) int {
+	if coach == 0 {
+		return self
+	}
+	switch b.Mode {
+	case BlendCoach:
+		return coach
+	case BlendSelf:
+		return self
+	}
+	w := min(max(b.SelfWeight, 0), 100)
+	return int(math.Floor(float64(self*w+coach*(100-w))/100 + 0.5))
+}
+
+// RatingPair is a finished session rated by both the user and the coach.
+type RatingPair struct {
+	SkillID    string
+	FinishedAt time.Time
+	Self       int
+	Coach      int
+}
+
+// GetRatingPairs returns sessions finished since the given time that have
+// both a self and a coach rating, oldest first.
+func (db *DB) GetRatingPairs(since time.Time) ([]RatingPair, error) {
+	rows, err := db.conn.Query(`
+		SELECT skill_id, finished_at, self_rating, coach_rating
+		FROM sessions
+		WHERE finished_at >= ? AND self_rating IS NOT NULL AND coach_rating IS NOT NULL
+		ORDER BY finished_at ASC
+	`, sqliteTime(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []RatingPair
+	for rows.Next() {
+		var p RatingPair
+		var finishedAt string
+		if err := rows.Scan(&p.SkillID, &finishedAt, &p.Self, &p.Coach); err != nil {
+			return nil, err
+		}
+		p.FinishedAt, err = time.Parse("2006-01-02 15:04:05", finishedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,30 @@
+package db
+
+import "testing"
+
+func TestRatingBlendCombine(t *testing.T) {
+	tests := []struct {
+		name        string
+		blend       RatingBlend
+		self, coach int
+		want        int
+	}{
+		{"coach", RatingBlend{Mode: BlendCoach}, 4, 2, 2},
+		{"self", RatingBlend{Mode: BlendSelf}, 4, 2, 4},
+		{"no coach rating in coach mode", RatingBlend{Mode: BlendCoach}, 3, 0, 3},
+		{"no coach rating in weighted mode", DefaultBlend, 2, 0, 2},
+		{"even split rounds up", DefaultBlend, 4, 3, 4},
+		{"even split", DefaultBlend, 4, 2, 3},
+		{"weight 0 is the coach", RatingBlend{Mode: BlendWeighted, SelfWeight: 0}, 4, 1, 1},
+		{"weight 1 is nearly the coach", RatingBlend{Mode: BlendWeighted, SelfWeight: 1}, 4, 1, 1},
+		{"weight 100 is self", RatingBlend{Mode: BlendWeighted, SelfWeight: 100}, 4, 1, 4},
+		{"weight 75", RatingBlend{Mode: BlendWeighted, SelfWeight: 75}, 4, 1, 3},
+		{"weight below 0 is clamped", RatingBlend{Mode: BlendWeighted, SelfWeight: -20}, 4, 1, 1},
+		{"weight above 100 is clamped", RatingBlend{Mode: BlendWeighted, SelfWeight: 150}, 4, 1, 4},
+	}
+	for _, tt := range tests {
+		if got := tt.blend.Combine(tt.self, tt.coach); got != tt.want {
+			t.Errorf("%s: Combine(%d, %d) = %d, want %d", tt.name, tt.self, tt.coach, got, tt.want)
+		}
+	}
+}
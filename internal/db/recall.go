@@ -0,0 +1,35 @@
+package db
+
+import "fmt"
+
+// PastAssessment is the coach's closing assessment of a finished session.
+type PastAssessment struct {
+	FinishedAt string
+	Assessment string
+}
+
+// GetAssessments returns the most recent coach assessments of a skill,
+// newest first.
+func (db *DB) GetAssessments(skillID string, limit int) ([]PastAssessment, error) {
+	rows, err := db.conn.Query(`
+		SELECT finished_at, assessment
+		FROM sessions
+		WHERE skill_id = ? AND finished_at IS NOT NULL AND assessment IS NOT NULL AND assessment != ''
+		ORDER BY finished_at DESC
+		LIMIT ?
+	`, skillID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query assessments: %w", err)
+	}
+	defer rows.Close()
+
+	var out []PastAssessment
+	for rows.Next() {
+		var a PastAssessment
+		if err := rows.Scan(&a.FinishedAt, &a.Assessment); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"bonk/internal/streak"
+)
+
+// GetStreak replays the days with a finished session, as the clock counts
+// them, into the current streak.
+func (db *DB) GetStreak(c streak.Clock) (streak.Status, error) {
+	rows, err := db.conn.Query(`SELECT finished_at FROM sessions WHERE finished_at IS NOT NULL`)
+	if err != nil {
+		return streak.Status{}, fmt.Errorf("query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var days []string
+	for rows.Next() {
+		var finished string
+		if err := rows.Scan(&finished); err != nil {
+			return streak.Status{}, err
+		}
+		t, err := time.ParseInLocation(time.DateTime, finished, time.UTC)
+		if err != nil {
+			continue
+		}
+		days = append(days, c.Day(t))
+	}
+	if err := rows.Err(); err != nil {
+		return streak.Status{}, err
+	}
+	return streak.Compute(days, c.Day(time.Now())), nil
+}
+
+// GetTodaySessionCount returns the sessions finished since today's
+// practice day began.
+func (db *DB) GetTodaySessionCount(c streak.Clock) (int, error) {
+	return db.countSessionsSince(c.StartOfDay(time.Now()))
+}
+
+// GetWeekSessionCount returns the sessions finished since this practice
+// week began, for the weekly goal.
+func (db *DB) GetWeekSessionCount(c streak.Clock) (int, error) {
+	return db.countSessionsSince(c.StartOfWeek(time.Now()))
+}
+
+func (db *DB) countSessionsSince(since time.Time) (int, error) {
+	var count int
+	err := db.conn.QueryRow(`
+		SELECT COUNT(*) FROM sessions
+		WHERE finished_at IS NOT NULL AND finished_at >= ?
+	`, sqliteTime(since)).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,170 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"bonk/internal/streak"
+)
+
+// TokenUsage counts the tokens billed for API calls.
+type TokenUsage struct {
+	Input      int
+	Output     int
+	CacheWrite int
+	CacheRead  int
+}
+
+// Total counts every billed token.
+func (u TokenUsage) Total() int {
+	return u.Input + u.Output + u.CacheWrite + u.CacheRead
+}
+
+// Add returns the sum of two usages.
+func (u TokenUsage) Add(o TokenUsage) TokenUsage {
+	return TokenUsage{
+		Input:      u.Input + o.Input,
+		Output:     u.Output + o.Output,
+		CacheWrite: u.CacheWrite + o.CacheWrite,
+		CacheRead:  u.CacheRead + o.CacheRead,
+	}
+}
+
+// Usage kinds
+const (
+	UsageDrill    = "drill"    // a coach turn during a drill
+	UsageFeedback = "feedback" // bonk review --feedback
+)
+
+// UsageRecord is one API call charged to a session.
+type UsageRecord struct {
+	SessionID string
+	Turn      int
+	Kind      string
+	Model     string
+	Tokens    TokenUsage
+}
+
+// RecordUsage stores the token usage of one API call.
+func (db *DB) RecordUsage(r UsageRecord) error {
+	_, err := db.conn.Exec(`
+		INSERT INTO api_usage (id, session_id, turn, kind, model,
+			input_tokens, output_tokens, cache_write_tokens, cache_read_tokens)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		uuid.New().String(), r.SessionID, r.Turn, r.Kind, r.Model,
+		r.Tokens.Input, r.Tokens.Output, r.Tokens.CacheWrite, r.Tokens.CacheRead,
+	)
+	if err != nil {
+		return fmt.Errorf("record usage: %w", err)
+	}
+	return nil
+}
+
+// UsageRow aggregates API calls by practice day, skill and model.
+type UsageRow struct {
+	Day     string // YYYY-MM-DD on the practice-day clock
+	SkillID string
+	Model   string
+	Calls   int
+	Tokens  TokenUsage
+}
+
+// GetUsage returns usage since the given time, grouped by c's practice
+// day, skill and model, newest day first.
+func (db *DB) GetUsage(c streak.Clock, since time.Time) ([]UsageRow, error) {
+	rows, err := db.conn.Query(`
+		SELECT u.created_at, s.skill_id, u.model,
+			u.input_tokens, u.output_tokens, u.cache_write_tokens, u.cache_read_tokens
+		FROM api_usage u
+		JOIN sessions s ON s.id = u.session_id
+		WHERE u.created_at >= ?
+	`, sqliteTime(since))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type key struct{ day, skillID, model string }
+	byKey := make(map[key]*UsageRow)
+	for rows.Next() {
+		var createdAt, skillID, model string
+		var u TokenUsage
+		if err := rows.Scan(&createdAt, &skillID, &model,
+			&u.Input, &u.Output, &u.CacheWrite, &u.CacheRead); err != nil {
+			return nil, err
+		}
+		at, err := time.Parse("2006-01-02 15:04:05", createdAt)
+		if err != nil {
+			return nil, err
+		}
+		k := key{c.Day(at), skillID, model}
+		if byKey[k] == nil {
+			byKey[k] = &UsageRow{Day: k.day, SkillID: skillID, Model: model}
+		}
+		byKey[k].Calls++
+		byKey[k].Tokens = byKey[k].Tokens.Add(u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]UsageRow, 0, len(byKey))
+	for _, r := range byKey {
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Day != b.Day {
+			return a.Day > b.Day
+		}
+		if a.SkillID != b.SkillID {
+			return a.SkillID < b.SkillID
+		}
+		return a.Model < b.Model
+	})
+	return result, nil
+}
+
+// GetSessionUsage returns a session's usage per model.
+func (db *DB) GetSessionUsage(sessionID string) (map[string]TokenUsage, error) {
+	rows, err := db.conn.Query(`
+		SELECT model, SUM(input_tokens), SUM(output_tokens), SUM(cache_write_tokens), SUM(cache_read_tokens)
+		FROM api_usage
+		WHERE session_id = ?
+		GROUP BY model
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	byModel := make(map[string]TokenUsage)
+	for rows.Next() {
+		var model string
+		var u TokenUsage
+		if err := rows.Scan(&model, &u.Input, &u.Output, &u.CacheWrite, &u.CacheRead); err != nil {
+			return nil, err
+		}
+		byModel[model] = u
+	}
+	return byModel, rows.Err()
+}
+
+// GetTokensSince returns the total tokens billed since the given time.
+func (db *DB) GetTokensSince(since time.Time) (int, error) {
+	var total int
+	err := db.conn.QueryRow(`
+		SELECT COALESCE(SUM(input_tokens + output_tokens + cache_write_tokens + cache_read_tokens), 0)
+		FROM api_usage
+		WHERE created_at >= ?
+	`, sqliteTime(since)).Scan(&total)
+	return total, err
+}
+
+// sqliteTime formats t like datetime('now') so it compares as text.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
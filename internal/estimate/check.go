@@ -0,0 +1,188 @@
+package estimate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Verdict grades an estimate by how far it is from the reference.
+type Verdict string
+
+const (
+	Close    Verdict = "close"                        // within 2x
+	Ballpark Verdict = "within an order of magnitude" // within 10x
+	Off      Verdict = "off"
+	Missing  Verdict = "no number" // nothing in the answer could be read as this step
+	GaveUp   Verdict = "gave up"   // the candidate asked for the answer
+)
+
+// Result is the check of one answer against one step.
+type Result struct {
+	Step    int // 0-based
+	Ask     string
+	Unit    Unit
+	Want    float64
+	Got     float64
+	Figure  string  // the figure taken as the answer, as written
+	Factor  float64 // how many times too high or low, >= 1
+	Verdict Verdict
+}
+
+// Passed reports whether the estimate is within an order of magnitude.
+func (r Result) Passed() bool {
+	return r.Verdict == Close || r.Verdict == Ballpark
+}
+
+// Summary is a one-line description for the candidate.
+func (r Result) Summary() string {
+	switch r.Verdict {
+	case Missing:
+		return fmt.Sprintf("%s: no number found", r.Ask)
+	case GaveUp:
+		return fmt.Sprintf("%s: gave up, reference %s", r.Ask, Format(r.Want, r.Unit))
+	}
+	return fmt.Sprintf("%s: you said %s, reference %s (%s, %s)",
+		r.Ask, Format(r.Got, r.Unit), Format(r.Want, r.Unit), r.Verdict, factor(r.Factor))
+}
+
+// Check reads the answer to a step. The last figure in the answer with a
+// matching unit is taken as the estimate, since answers usually end on
+// their conclusion; numbers without a unit are only used when there is none.
+func Check(step Step, answer string) Result {
+	r := Result{Ask: step.Ask, Unit: step.Unit, Want: step.Value, Verdict: Missing}
+	var best *Figure
+	var bestValue float64
+	for _, f := range ParseFigures(answer) {
+		v, ok := f.In(step.Unit)
+		if !ok || v <= 0 {
+			continue
+		}
+		if best == nil || f.Typed || !best.Typed {
+			best, bestValue = &f, v
+		}
+	}
+	if best == nil {
+		return r
+	}
+	r.Got, r.Figure = bestValue, best.Text
+	r.Factor = math.Max(r.Got/r.Want, r.Want/r.Got)
+	switch {
+	case r.Factor <= 2:
+		r.Verdict = Close
+	case r.Factor <= 10:
+		r.Verdict = Ballpark
+	default:
+		r.Verdict = Off
+	}
+	return r
+}
+
+// factor renders how many times off an estimate is: "1.2x", "40x".
+func factor(f float64) string {
+	if f >= 10 {
+		return fmt.Sprintf("%.0fx", f)
+	}
+	return fmt.Sprintf("%.1fx", f)
+}
+
+// Drill walks a scenario step by step.
+type Drill struct {
+	Scenario *Scenario
+	Results  []Result // one per step answered
+}
+
+// NewDrill starts a drill at the first step.
+func NewDrill(s *Scenario) *Drill {
+	return &Drill{Scenario: s}
+}
+
+// Done reports whether every step has an answer.
+func (d *Drill) Done() bool {
+	return len(d.Results) >= len(d.Scenario.Steps)
+}
+
+// Check grades an answer to the current step without moving on, so a
+// failed request can be retried.
+func (d *Drill) Check(answer string) (Result, bool) {
+	if d.Done() {
+		return Result{}, false
+	}
+	r := Check(d.Scenario.Steps[len(d.Results)], answer)
+	r.Step = len(d.Results)
+	return r, true
+}
+
+// GiveUp marks the current step as given up, for recording like an answer
+// once the coach has revealed it.
+func (d *Drill) GiveUp() (Result, bool) {
+	if d.Done() {
+		return Result{}, false
+	}
+	step := d.Scenario.Steps[len(d.Results)]
+	return Result{Step: len(d.Results), Ask: step.Ask, Unit: step.Unit, Want: step.Value, Verdict: GaveUp}, true
+}
+
+// Record keeps a result and moves to the next step. An answer without a
+// number leaves the step open.
+func (d *Drill) Record(r Result) {
+	if r.Verdict != Missing && r.Step == len(d.Results) {
+		d.Results = append(d.Results, r)
+	}
+}
+
+// Score turns the results into a 1-4 rating: 4 if every number is close,
+// 3 if all are within an order of magnitude, 2 with one off, 1 otherwise.
+// It is 0 before any step is answered.
+func (d *Drill) Score() int {
+	if len(d.Results) == 0 {
+		return 0
+	}
+	near, off := 0, 0
+	for _, r := range d.Results {
+		switch {
+		case r.Verdict == Close:
+			near++
+		case !r.Passed():
+			off++
+		}
+	}
+	switch {
+	case near == len(d.Results):
+		return 4
+	case off == 0:
+		return 3
+	case off == 1:
+		return 2
+	}
+	return 1
+}
+
+// Note is what the coach is told about a checked answer: the verdict and
+// what to do next.
+func (d *Drill) Note(r Result) string {
+	steps := len(d.Scenario.Steps)
+	var b strings.Builder
+	fmt.Fprintf(&b, "[bonk check] Step %d of %d, %s: ", r.Step+1, steps, r.Ask)
+	switch r.Verdict {
+	case Missing:
+		b.WriteString("no number found in the answer. Ask them to commit to one.")
+		return b.String()
+	case GaveUp:
+		fmt.Fprintf(&b, "the candidate gave up; reference %s.", Format(r.Want, r.Unit))
+	default:
+		fmt.Fprintf(&b, "read %q as %s; reference %s; %s, %s.",
+			r.Figure, Format(r.Got, r.Unit), Format(r.Want, r.Unit), factor(r.Factor), r.Verdict)
+	}
+	critique := "Critique the reasoning"
+	if r.Verdict == GaveUp {
+		critique = "Walk through the reference working"
+	}
+	if r.Step+1 < steps {
+		fmt.Fprintf(&b, " %s, then ask for step %d: %s.", critique, r.Step+2, d.Scenario.Steps[r.Step+1].Ask)
+		return b.String()
+	}
+	after := &Drill{Scenario: d.Scenario, Results: append(d.Results[:len(d.Results):len(d.Results)], r)}
+	fmt.Fprintf(&b, " That was the last step; the numbers score %d/4. %s and give the final assessment.", after.Score(), critique)
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package estimate
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestParseFigures(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		unit Unit
+	}{
+		{"2.3k/s", 2300, Rate},
+		{"1,200 QPS", 1200, Rate},
+		{"about 40 GB per day", 40e9, DailyData},
+		{"1.5 x 10^6 requests a day", 1.5e6, Unit{Per: PerDay}},
+		{"3.5e4 writes/sec", 3.5e4, Rate},
+		{"~2 million", 2e6, Unit{}},
+		{"12 TB", 12e12, Storage},
+		{"8 Gbps", 1e9, Bandwidth},
+		{"350 MB/s", 350e6, Bandwidth},
+		{"1 KiB", 1024, Storage},
+		{"5B", 5e9, Unit{}},
+	}
+	for _, tt := range tests {
+		figs := ParseFigures(tt.in)
+		if len(figs) != 1 {
+			t.Errorf("ParseFigures(%q) = %+v, want one figure", tt.in, figs)
+			continue
+		}
+		if f := figs[0]; math.Abs(f.Value-tt.want) > 1e-6*tt.want || f.Unit != tt.unit {
+			t.Errorf("ParseFigures(%q) = %v %+v, want %v %+v", tt.in, f.Value, f.Unit, tt.want, tt.unit)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for _, tt := range []struct {
+		v    float64
+		u    Unit
+		want string
+	}{
+		{11574.07, Rate, "11.6k/s"},
+		{2.4e12, Storage, "2.4 TB"},
+		{350e6, Bandwidth, "350 MB/s"},
+		{500, Unit{}, "500"},
+		{5e9, DailyData, "5 GB/day"},
+	} {
+		if got := Format(tt.v, tt.u); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTakesLastTypedFigure(t *testing.T) {
+	step := Step{Ask: "average write QPS", Unit: Rate, Value: 100e6 * 2 / 86400}
+	r := Check(step, "100M users × 2 = 200M writes per day, / 86400 ≈ 2.3k/s, call it 2,000")
+	if r.Figure != "2.3k/s" || r.Verdict != Close {
+		t.Errorf("result = %+v", r)
+	}
+	// Daily figures convert to per second.
+	if r := Check(step, "so 200 million a day"); r.Verdict != Close {
+		t.Errorf("daily figure: %+v", r)
+	}
+	if r := Check(step, "roughly 200 QPS"); r.Verdict != Off || r.Factor < 10 {
+		t.Errorf("200 QPS: %+v", r)
+	}
+	if r := Check(step, "20k/s"); r.Verdict != Ballpark {
+		t.Errorf("20k/s: %+v", r)
+	}
+	if r := Check(step, "it depends on the read ratio"); r.Verdict != Missing {
+		t.Errorf("no number: %+v", r)
+	}
+	// Bytes never answer a rate.
+	if r := Check(step, "2 GB"); r.Verdict != Missing {
+		t.Errorf("bytes for a rate: %+v", r)
+	}
+}
+
+func TestDrill(t *testing.T) {
+	sc, err := Generate("estimate-storage", rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDrill(sc)
+
+	r, ok := d.Check("no idea")
+	if !ok || r.Verdict != Missing || !strings.Contains(d.Note(r), "Step 1 of 2") {
+		t.Fatalf("missing answer: %+v", r)
+	}
+	d.Record(r)
+	if len(d.Results) != 0 {
+		t.Fatal("an answer without a number moved the drill on")
+	}
+
+	first := Format(sc.Steps[0].Value, sc.Steps[0].Unit)
+	r, _ = d.Check("that comes to " + first)
+	if r.Verdict != Close || !strings.Contains(d.Note(r), "step 2") {
+		t.Fatalf("step 1: %+v, note %q", r, d.Note(r))
+	}
+	d.Record(r)
+
+	r, _ = d.Check("about 1 KB in total")
+	note := d.Note(r)
+	if r.Verdict != Off || !strings.Contains(note, "score 2/4") || !strings.Contains(note, "final assessment") {
+		t.Fatalf("step 2: %+v, note %q", r, note)
+	}
+	d.Record(r)
+	if !d.Done() || d.Score() != 2 {
+		t.Errorf("done = %v, score = %d", d.Done(), d.Score())
+	}
+	if _, ok := d.Check("more"); ok {
+		t.Error("checked an answer after the last step")
+	}
+}
+
+func TestDrillGiveUp(t *testing.T) {
+	sc, err := Generate("estimate-qps", rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDrill(sc)
+	r, ok := d.GiveUp()
+	if !ok || r.Verdict != GaveUp || r.Passed() {
+		t.Fatalf("give up: %+v", r)
+	}
+	if note := d.Note(r); !strings.Contains(note, "gave up") || !strings.Contains(note, "reference working") {
+		t.Errorf("note %q", note)
+	}
+	d.Record(r)
+	if len(d.Results) != 1 {
+		t.Error("giving up did not move the drill on")
+	}
+}
+
+func TestGenerateEverySkill(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, id := range Skills {
+		for i := 0; i < 20; i++ {
+			sc, err := Generate(id, rng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, st := range sc.Steps {
+				// The reference answer must read back as itself.
+				if r := Check(st, Format(st.Value, st.Unit)); r.Verdict != Close {
+					t.Errorf("%s: %s = %s reads back as %+v", id, st.Ask, Format(st.Value, st.Unit), r)
+				}
+			}
+		}
+	}
+	if _, err := Generate("hash-maps", rng); err == nil {
+		t.Error("expected an error for a skill without scenarios")
+	}
+}
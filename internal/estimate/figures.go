@@ -0,0 +1,169 @@
+package estimate
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Period is the time unit a rate is expressed in.
+type Period int
+
+const (
+	PerNone Period = iota
+	PerSecond
+	PerDay
+)
+
+const secondsPerDay = 86400
+
+// Unit is what a quantity measures: a count or a number of bytes, either
+// outright or per period.
+type Unit struct {
+	Bytes bool
+	Per   Period
+}
+
+var (
+	Rate      = Unit{Per: PerSecond}              // requests per second
+	Storage   = Unit{Bytes: true}                 // bytes
+	DailyData = Unit{Bytes: true, Per: PerDay}    // bytes per day
+	Bandwidth = Unit{Bytes: true, Per: PerSecond} // bytes per second
+)
+
+// Figure is a number read from an answer, normalized to bytes and seconds
+// where its unit says so.
+type Figure struct {
+	Value float64
+	Unit  Unit
+	Text  string // as written
+	Typed bool   // had a unit or rate after it
+}
+
+// figureRe matches a number with an optional exponent, scale word, unit and
+// rate: "2.3k/s", "1,200 QPS", "40 GB per day", "1.5 x 10^6 requests a day".
+// A bare "b" is read as billion; bytes need a prefix (KB, MB, ...) or "bytes".
+var figureRe = regexp.MustCompile(`(?i)((?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?|\.\d+)` +
+	`(?:\s*(?:e|[x*×]\s*10\^)\s*(\d+))?` +
+	`(?:\s*(thousand|million|billion|trillion|mil|bn|k|m|b|t)\b)?` +
+	`(?:\s*(kib|mib|gib|tib|pib|kb|mb|gb|tb|pb|eb|bytes?|kbps|mbps|gbps|tbps|bps|qps|rps|tps)\b)?` +
+	`(?:\s*(?:[a-z]+\s*)?(/\s*s(?:ec(?:ond)?)?|per\s+sec(?:ond)?|/\s*day|per\s+day|a\s+day|/\s*d)\b)?`)
+
+var scales = map[string]float64{
+	"thousand": 1e3, "k": 1e3,
+	"million": 1e6, "mil": 1e6, "m": 1e6,
+	"billion": 1e9, "bn": 1e9, "b": 1e9,
+	"trillion": 1e12, "t": 1e12,
+}
+
+var byteUnits = map[string]float64{
+	"byte": 1, "bytes": 1,
+	"kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12, "pb": 1e15, "eb": 1e18,
+	"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50,
+}
+
+var bitRates = map[string]float64{
+	"bps": 1, "kbps": 1e3, "mbps": 1e6, "gbps": 1e9, "tbps": 1e12,
+}
+
+// ParseFigures returns every number in s, in order.
+func ParseFigures(s string) []Figure {
+	var figures []Figure
+	for _, m := range figureRe.FindAllStringSubmatch(s, -1) {
+		v, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		if m[2] != "" {
+			exp, _ := strconv.Atoi(m[2])
+			v *= math.Pow10(exp)
+		}
+		f := Figure{Text: strings.TrimSpace(m[0])}
+		if scale := strings.ToLower(m[3]); scale != "" {
+			v *= scales[scale]
+		}
+		switch unit := strings.ToLower(m[4]); {
+		case byteUnits[unit] > 0:
+			v *= byteUnits[unit]
+			f.Unit.Bytes = true
+			f.Typed = true
+		case bitRates[unit] > 0:
+			v *= bitRates[unit] / 8
+			f.Unit = Bandwidth
+			f.Typed = true
+		case unit != "": // qps, rps, tps
+			f.Unit.Per = PerSecond
+			f.Typed = true
+		}
+		if rate := strings.ToLower(m[5]); rate != "" {
+			f.Typed = true
+			if strings.Contains(rate, "d") {
+				f.Unit.Per = PerDay
+			} else {
+				f.Unit.Per = PerSecond
+			}
+		}
+		f.Value = v
+		figures = append(figures, f)
+	}
+	return figures
+}
+
+// In converts the figure to unit u. It reports false if the figure measures
+// something else; figures without a unit convert to anything.
+func (f Figure) In(u Unit) (float64, bool) {
+	if !f.Typed {
+		return f.Value, true
+	}
+	if f.Unit.Bytes != u.Bytes {
+		return 0, false
+	}
+	switch {
+	case f.Unit.Per == u.Per, f.Unit.Per == PerNone:
+		return f.Value, true
+	case f.Unit.Per == PerDay && u.Per == PerSecond:
+		return f.Value / secondsPerDay, true
+	case f.Unit.Per == PerSecond && u.Per == PerDay:
+		return f.Value * secondsPerDay, true
+	}
+	return 0, false
+}
+
+// Format renders a value in unit u with about three significant digits:
+// "11.6k/s", "2.4 TB", "350 MB/s".
+func Format(v float64, u Unit) string {
+	var s string
+	if u.Bytes {
+		s = scaled(v, []string{" bytes", " KB", " MB", " GB", " TB", " PB", " EB"})
+	} else {
+		s = scaled(v, []string{"", "k", "M", "B", "T"})
+	}
+	switch u.Per {
+	case PerSecond:
+		s += "/s"
+	case PerDay:
+		s += "/day"
+	}
+	return s
+}
+
+func scaled(v float64, suffixes []string) string {
+	i := 0
+	for math.Abs(v) >= 1000 && i < len(suffixes)-1 {
+		v /= 1000
+		i++
+	}
+	prec := 0
+	switch a := math.Abs(v); {
+	case a < 10:
+		prec = 2
+	case a < 100:
+		prec = 1
+	}
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s + suffixes[i]
+}
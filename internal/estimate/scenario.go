@@ -0,0 +1,169 @@
+// Package estimate generates back-of-the-envelope estimation scenarios and
+// checks the numbers in an answer against them.
+package estimate
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Step is one number the candidate has to estimate.
+type Step struct {
+	Ask     string // e.g. "average write QPS"
+	Unit    Unit
+	Value   float64 // reference answer
+	Working string  // how the reference answer is derived
+}
+
+// Scenario is a described system and the steps to estimate for it.
+type Scenario struct {
+	System string
+	Facts  []string
+	Steps  []Step
+}
+
+// system is a product the scenarios are set in.
+type system struct {
+	name   string  // "a photo sharing app"
+	object string  // what users create: "photo"
+	write  string  // "upload"
+	read   string  // past participle: "viewed"
+	size   float64 // bytes per object
+}
+
+var systems = []system{
+	{"a photo sharing app", "photo", "upload", "viewed", 2e6},
+	{"a microblogging service", "post", "publish", "read", 1e3},
+	{"a URL shortener", "short link", "create", "followed", 500},
+	{"a chat app", "message", "send", "read", 200},
+	{"a video platform", "video", "upload", "watched", 50e6},
+	{"a food delivery app", "order", "place", "tracked", 5e3},
+	{"a code hosting site", "commit", "push", "fetched", 20e3},
+}
+
+var (
+	dailyUsers   = []float64{1e6, 10e6, 50e6, 100e6, 200e6, 500e6}
+	writesPerDay = []float64{1, 2, 5, 10}
+	readRatios   = []float64{10, 20, 50, 100}
+	peakFactors  = []float64{2, 3, 5}
+	retention    = []float64{1, 3, 5, 10}
+)
+
+const replicas = 3
+
+// Skills lists the skill IDs Generate has scenarios for.
+var Skills = []string{"estimate-qps", "estimate-storage", "estimate-bandwidth"}
+
+// Generate makes a scenario for an estimation skill.
+func Generate(skillID string, rng *rand.Rand) (*Scenario, error) {
+	pick := func(xs []float64) float64 { return xs[rng.Intn(len(xs))] }
+	sys := systems[rng.Intn(len(systems))]
+	users, writes := pick(dailyUsers), pick(writesPerDay)
+	perDay := users * writes
+	dau := fmt.Sprintf("%s daily active users", Format(users, Unit{}))
+	writeFact := fmt.Sprintf("each user will %s %v %s a day on average", sys.write, writes, plural(sys.object, writes))
+
+	sc := &Scenario{System: sys.name}
+	switch skillID {
+	case "estimate-qps":
+		ratio, peak := pick(readRatios), pick(peakFactors)
+		sc.Facts = []string{dau, writeFact,
+			fmt.Sprintf("%ss are %s %v times for every one created", sys.object, sys.read, ratio),
+			fmt.Sprintf("peak traffic is %vx the daily average", peak),
+		}
+		sc.Steps = []Step{
+			{
+				Ask:     "average write QPS",
+				Unit:    Rate,
+				Value:   perDay / secondsPerDay,
+				Working: fmt.Sprintf("%s users × %v / 86,400 s", Format(users, Unit{}), writes),
+			},
+			{
+				Ask:     "peak read QPS",
+				Unit:    Rate,
+				Value:   perDay * ratio * peak / secondsPerDay,
+				Working: fmt.Sprintf("average writes × %v reads × %vx peak", ratio, peak),
+			},
+		}
+	case "estimate-storage":
+		keep := pick(retention)
+		daily := perDay * sys.size
+		sc.Facts = []string{dau, writeFact,
+			fmt.Sprintf("a %s takes about %s including metadata", sys.object, Format(sys.size, Storage)),
+			fmt.Sprintf("data is kept for %s with %d replicas", years(keep), replicas),
+		}
+		sc.Steps = []Step{
+			{
+				Ask:     "new data per day (one copy)",
+				Unit:    DailyData,
+				Value:   daily,
+				Working: fmt.Sprintf("%s users × %v × %s", Format(users, Unit{}), writes, Format(sys.size, Storage)),
+			},
+			{
+				Ask:     fmt.Sprintf("total storage after %s, all replicas", years(keep)),
+				Unit:    Storage,
+				Value:   daily * 365 * keep * replicas,
+				Working: fmt.Sprintf("daily data × 365 × %s × %d replicas", years(keep), replicas),
+			},
+		}
+	case "estimate-bandwidth":
+		ratio, peak := pick(readRatios), pick(peakFactors)
+		sc.Facts = []string{dau, writeFact,
+			fmt.Sprintf("a %s is about %s", sys.object, Format(sys.size, Storage)),
+			fmt.Sprintf("every %s is %s %v times on average", sys.object, sys.read, ratio),
+			fmt.Sprintf("peak traffic is %vx the daily average", peak),
+		}
+		sc.Steps = []Step{
+			{
+				Ask:     "peak ingress bandwidth",
+				Unit:    Bandwidth,
+				Value:   perDay * sys.size * peak / secondsPerDay,
+				Working: fmt.Sprintf("%s users × %v × %s / 86,400 s × %vx peak", Format(users, Unit{}), writes, Format(sys.size, Storage), peak),
+			},
+			{
+				Ask:     "average egress bandwidth",
+				Unit:    Bandwidth,
+				Value:   perDay * sys.size * ratio / secondsPerDay,
+				Working: fmt.Sprintf("average ingress × %v reads", ratio),
+			},
+		}
+	default:
+		return nil, fmt.Errorf("no estimation scenarios for skill %q", skillID)
+	}
+	return sc, nil
+}
+
+// Brief is the scenario as given to the candidate.
+func (s *Scenario) Brief() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "System: %s\n", s.System)
+	for _, f := range s.Facts {
+		fmt.Fprintf(&b, "- %s\n", f)
+	}
+	b.WriteString("Estimate, in order:\n")
+	for i, st := range s.Steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, st.Ask)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Reference lists each step's reference answer and how it is derived.
+func (s *Scenario) Reference() string {
+	var b strings.Builder
+	for i, st := range s.Steps {
+		fmt.Fprintf(&b, "%d. %s ≈ %s (%s)\n", i+1, st.Ask, Format(st.Value, st.Unit), st.Working)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func years(n float64) string {
+	return fmt.Sprintf("%v %s", n, plural("year", n))
+}
+
+func plural(word string, n float64) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,96 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"bonk/internal/llm"
+	"bonk/internal/skills"
+)
+
+// Candidate answers the coach during an evaluation drill.
+type Candidate interface {
+	Name() string
+	// Strength is how good the candidate is meant to be, on the coach's
+	// 1-4 rating scale, so the final rating can be checked against it.
+	Strength() int
+	Answer(ctx context.Context, skill *skills.Skill, history []llm.ExchangeData, question string) (*llm.CandidateReply, error)
+}
+
+// Script is a candidate with canned answers, given in order. When they run
+// out the candidate says it doesn't know.
+type Script struct {
+	ID      string   `json:"name"`
+	Skill   string   `json:"skill,omitempty"` // empty for any skill
+	Level   int      `json:"strength"`
+	Answers []string `json:"answers"`
+}
+
+func (s *Script) Name() string  { return s.ID }
+func (s *Script) Strength() int { return s.Level }
+
+func (s *Script) Answer(ctx context.Context, skill *skills.Skill, history []llm.ExchangeData, question string) (*llm.CandidateReply, error) {
+	if i := len(history); i < len(s.Answers) {
+		return &llm.CandidateReply{Text: s.Answers[i]}, nil
+	}
+	return &llm.CandidateReply{Text: "I'm not sure."}, nil
+}
+
+// Fixture is a file of scripted candidates:
+//
+//	{"candidates": [{"name": "weak", "skill": "hash-maps", "strength": 1, "answers": ["..."]}]}
+type Fixture struct {
+	Candidates []*Script `json:"candidates"`
+}
+
+// LoadFixture reads and checks a fixture file.
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+	for i, c := range f.Candidates {
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("script-%d", i+1)
+		}
+		if c.Level < 1 || c.Level > 4 {
+			return nil, fmt.Errorf("fixture %s: candidate %s: strength must be 1-4", path, c.ID)
+		}
+		if c.Skill != "" && skills.Get(c.Skill) == nil {
+			return nil, fmt.Errorf("fixture %s: candidate %s: unknown skill %q", path, c.ID, c.Skill)
+		}
+		if len(c.Answers) == 0 {
+			return nil, fmt.Errorf("fixture %s: candidate %s has no answers", path, c.ID)
+		}
+	}
+	return &f, nil
+}
+
+// For returns the scripted candidates that apply to a skill.
+func (f *Fixture) For(skillID string) []Candidate {
+	var result []Candidate
+	for _, c := range f.Candidates {
+		if c.Skill == "" || c.Skill == skillID {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Simulated is a second model playing a candidate of a given strength.
+type Simulated struct {
+	Level int
+}
+
+func (s Simulated) Name() string  { return fmt.Sprintf("llm-%d", s.Level) }
+func (s Simulated) Strength() int { return s.Level }
+
+func (s Simulated) Answer(ctx context.Context, skill *skills.Skill, history []llm.ExchangeData, question string) (*llm.CandidateReply, error) {
+	return llm.SimulateCandidate(ctx, skill, s.Level, history, question)
+}
@@ -0,0 +1,247 @@
+// Package eval runs coaching drills against simulated candidates and scores
+// how the coach behaves, so prompt and model changes can be compared.
+package eval
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"bonk/internal/llm"
+	"bonk/internal/skills"
+)
+
+// Options control an evaluation run.
+type Options struct {
+	Skills            []*skills.Skill
+	Candidates        func(skill *skills.Skill) []Candidate
+	Runs              int // drills per skill and candidate
+	MaxTurns          int
+	PracticalMaxTurns int
+	Seed              int64                            // run n draws estimation scenarios from Seed+n
+	Progress          func(format string, args ...any) // optional
+}
+
+// Drill is the outcome of one evaluation drill.
+type Drill struct {
+	Skill         string   `json:"skill"`
+	Domain        string   `json:"domain"`
+	Candidate     string   `json:"candidate"`
+	Strength      int      `json:"strength"`
+	Run           int      `json:"run"`
+	PromptVersion string   `json:"prompt_version"`
+	Model         string   `json:"model"`
+	Turns         int      `json:"turns"`          // coach replies, including the final one
+	Final         bool     `json:"final"`          // the coach ended the drill itself
+	Rating        int      `json:"rating"`         // final rating, 0 if none
+	MetaClean     int      `json:"meta_clean"`     // replies with valid metadata on the first try
+	MetaInvalid   int      `json:"meta_invalid"`   // replies still invalid after re-prompting
+	Facets        []string `json:"facets"`         // facets the coach reported
+	Covered       []string `json:"covered_facets"` // skill facets those match
+	FacetCoverage float64  `json:"facet_coverage"`
+	Leaks         []string `json:"leaks,omitempty"` // system-design-practical only
+	Tokens        int      `json:"tokens"`
+	Cost          float64  `json:"cost"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// Run plays every drill in turn. A drill that fails is recorded with its
+// error; only a cancelled context stops the run.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Runs < 1 {
+		opts.Runs = 1
+	}
+	report := &Report{
+		Started: time.Now(),
+		Seed:    opts.Seed,
+		Prompts: make(map[string]string),
+	}
+	for _, skill := range opts.Skills {
+		for _, cand := range opts.Candidates(skill) {
+			for run := 1; run <= opts.Runs; run++ {
+				if opts.Progress != nil {
+					opts.Progress("%s / %s (strength %d) run %d\n", skill.ID, cand.Name(), cand.Strength(), run)
+				}
+				maxTurns := opts.MaxTurns
+				if skill.Domain == "system-design-practical" {
+					maxTurns = opts.PracticalMaxTurns
+				}
+				rng := rand.New(rand.NewSource(opts.Seed + int64(run)))
+				d := runDrill(ctx, skill, cand, maxTurns, rng)
+				d.Run = run
+				report.Drills = append(report.Drills, d)
+				if d.PromptVersion != "" {
+					name, _, _ := strings.Cut(d.PromptVersion, "@")
+					report.Prompts[name] = d.PromptVersion
+				}
+				if report.Model == "" {
+					report.Model = d.Model
+				}
+				if err := ctx.Err(); err != nil {
+					report.Summary = summarize(report.Drills)
+					return report, err
+				}
+			}
+		}
+	}
+	report.Summary = summarize(report.Drills)
+	return report, nil
+}
+
+func runDrill(ctx context.Context, skill *skills.Skill, cand Candidate, maxTurns int, rng *rand.Rand) Drill {
+	d := Drill{
+		Skill:     skill.ID,
+		Domain:    skill.Domain,
+		Candidate: cand.Name(),
+		Strength:  cand.Strength(),
+	}
+	conv, err := llm.NewConversation(skill, llm.History{}, nil, maxTurns, rng)
+	if err != nil {
+		d.Error = err.Error()
+		return d
+	}
+	d.PromptVersion = conv.PromptVersion()
+
+	var history []llm.ExchangeData
+	var candidateSaid strings.Builder
+	answer := ""
+	for {
+		resp, err := conv.Send(ctx, answer)
+		if err != nil {
+			d.Error = err.Error()
+			break
+		}
+		d.Turns++
+		d.Model = resp.Model
+		d.addUsage(resp.Model, resp.Usage)
+		if resp.Reprompts == 0 && len(resp.MetaIssues) == 0 {
+			d.MetaClean++
+		}
+		if len(resp.MetaIssues) > 0 {
+			d.MetaInvalid++
+		}
+		if resp.Facet != "" {
+			d.Facets = append(d.Facets, resp.Facet)
+		}
+		if resp.IsFinal {
+			d.Final = true
+			d.Rating = resp.LLMRating
+			break
+		}
+		if skill.Domain == "system-design-practical" {
+			for _, leak := range findLeaks(resp.Text, candidateSaid.String()) {
+				d.Leaks = append(d.Leaks, fmt.Sprintf("turn %d: %s", d.Turns, leak))
+			}
+		}
+		// Past the limit the coach was told to wrap up; give it one more turn.
+		if maxTurns > 0 && d.Turns > maxTurns {
+			break
+		}
+
+		reply, err := cand.Answer(ctx, skill, history, resp.Text)
+		if err != nil {
+			d.Error = fmt.Sprintf("candidate: %v", err)
+			break
+		}
+		d.addUsage(reply.Model, reply.Usage)
+		history = append(history, llm.ExchangeData{Question: resp.Text, Answer: reply.Text})
+		candidateSaid.WriteString(reply.Text + "\n")
+		answer = reply.Text
+	}
+
+	d.Covered = coveredFacets(skill.Facets, d.Facets)
+	if len(skill.Facets) > 0 {
+		d.FacetCoverage = float64(len(d.Covered)) / float64(len(skill.Facets))
+	}
+	return d
+}
+
+func (d *Drill) addUsage(model string, u llm.Usage) {
+	if model == "" {
+		return // scripted answers
+	}
+	d.Tokens += u.Total()
+	cost, _ := llm.Cost(model, u)
+	d.Cost += cost
+}
+
+// Report is the result of an evaluation run.
+type Report struct {
+	Started time.Time         `json:"started"`
+	Model   string            `json:"model"`
+	Seed    int64             `json:"seed"`
+	Prompts map[string]string `json:"prompts"` // template name -> version
+	Summary Summary           `json:"summary"`
+	Drills  []Drill           `json:"drills"`
+}
+
+// Summary scores the coach across all drills.
+type Summary struct {
+	Drills            int      `json:"drills"`
+	Errors            int      `json:"errors"`
+	MetaCompliance    float64  `json:"meta_compliance"`    // replies valid on the first try
+	MetaValid         float64  `json:"meta_valid"`         // replies valid after re-prompting
+	FinishRate        float64  `json:"finish_rate"`        // drills the coach ended itself
+	AvgTurnsToFinal   float64  `json:"avg_turns_to_final"` // over finished drills
+	RatingCorrelation *float64 `json:"rating_correlation"` // final rating vs strength; nil without enough spread
+	RatingError       float64  `json:"rating_error"`       // mean |rating - strength|
+	FacetCoverage     float64  `json:"facet_coverage"`     // mean share of skill facets probed
+	LeakyDrills       int      `json:"leaky_drills"`       // system-design-practical drills with leaks
+	PracticalDrills   int      `json:"practical_drills"`   // system-design-practical drills run
+	Tokens            int      `json:"tokens"`
+	Cost              float64  `json:"cost"`
+}
+
+func summarize(drills []Drill) Summary {
+	s := Summary{Drills: len(drills)}
+	var replies, clean, valid, finished, turns int
+	var strengths, ratings []float64
+	var ratingErr, coverage float64
+	for _, d := range drills {
+		if d.Error != "" {
+			s.Errors++
+		}
+		replies += d.Turns
+		clean += d.MetaClean
+		valid += d.Turns - d.MetaInvalid
+		if d.Final {
+			finished++
+			turns += d.Turns
+		}
+		if d.Final && d.Rating > 0 {
+			strengths = append(strengths, float64(d.Strength))
+			ratings = append(ratings, float64(d.Rating))
+			ratingErr += math.Abs(float64(d.Rating - d.Strength))
+		}
+		coverage += d.FacetCoverage
+		if d.Domain == "system-design-practical" {
+			s.PracticalDrills++
+			if len(d.Leaks) > 0 {
+				s.LeakyDrills++
+			}
+		}
+		s.Tokens += d.Tokens
+		s.Cost += d.Cost
+	}
+	if replies > 0 {
+		s.MetaCompliance = float64(clean) / float64(replies)
+		s.MetaValid = float64(valid) / float64(replies)
+	}
+	if len(drills) > 0 {
+		s.FinishRate = float64(finished) / float64(len(drills))
+		s.FacetCoverage = coverage / float64(len(drills))
+	}
+	if finished > 0 {
+		s.AvgTurnsToFinal = float64(turns) / float64(finished)
+	}
+	if r := correlation(strengths, ratings); !math.IsNaN(r) {
+		s.RatingCorrelation = &r
+	}
+	if len(ratings) > 0 {
+		s.RatingError = ratingErr / float64(len(ratings))
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteMarkdown writes the report as a markdown document.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	s := r.Summary
+
+	fmt.Fprintf(&b, "# Coach evaluation\n\n")
+	fmt.Fprintf(&b, "- Date: %s\n", r.Started.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "- Model: %s\n", valueOr(r.Model, "unknown"))
+	fmt.Fprintf(&b, "- Seed: %d\n", r.Seed)
+	names := make([]string, 0, len(r.Prompts))
+	for name := range r.Prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "- Prompt: %s\n", r.Prompts[name])
+	}
+	fmt.Fprintf(&b, "- Usage: %d tokens, ~$%.2f\n\n", s.Tokens, s.Cost)
+
+	fmt.Fprintf(&b, "## Summary\n\n")
+	fmt.Fprintf(&b, "| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Drills | %d (%d errors) |\n", s.Drills, s.Errors)
+	fmt.Fprintf(&b, "| Metadata valid first try | %s |\n", percent(s.MetaCompliance))
+	fmt.Fprintf(&b, "| Metadata valid after re-prompts | %s |\n", percent(s.MetaValid))
+	fmt.Fprintf(&b, "| Coach ended the drill | %s |\n", percent(s.FinishRate))
+	fmt.Fprintf(&b, "| Avg turns to final | %.1f |\n", s.AvgTurnsToFinal)
+	corr := "n/a"
+	if s.RatingCorrelation != nil {
+		corr = fmt.Sprintf("%.2f", *s.RatingCorrelation)
+	}
+	fmt.Fprintf(&b, "| Rating vs strength correlation | %s |\n", corr)
+	fmt.Fprintf(&b, "| Mean rating error | %.2f |\n", s.RatingError)
+	fmt.Fprintf(&b, "| Facet coverage | %s |\n", percent(s.FacetCoverage))
+	if s.PracticalDrills > 0 {
+		fmt.Fprintf(&b, "| Practical drills with answer leaks | %d of %d |\n", s.LeakyDrills, s.PracticalDrills)
+	}
+
+	fmt.Fprintf(&b, "\n## Drills\n\n")
+	fmt.Fprintf(&b, "| Skill | Candidate | Strength | Run | Turns | Final | Rating | Meta | Facets | Leaks |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|---|---|---|---|---|\n")
+	for _, d := range r.Drills {
+		final := "no"
+		if d.Final {
+			final = "yes"
+		}
+		if d.Error != "" {
+			final = "error"
+		}
+		rating := "-"
+		if d.Rating > 0 {
+			rating = fmt.Sprintf("%d", d.Rating)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %d | %d | %s | %s | %d/%d | %s | %d |\n",
+			d.Skill, d.Candidate, d.Strength, d.Run, d.Turns, final, rating,
+			d.MetaClean, d.Turns, percent(d.FacetCoverage), len(d.Leaks))
+	}
+
+	var details strings.Builder
+	for _, d := range r.Drills {
+		if d.Error == "" && len(d.Leaks) == 0 {
+			continue
+		}
+		fmt.Fprintf(&details, "\n### %s / %s run %d\n\n", d.Skill, d.Candidate, d.Run)
+		if d.Error != "" {
+			fmt.Fprintf(&details, "Error: %s\n", d.Error)
+		}
+		for _, leak := range d.Leaks {
+			fmt.Fprintf(&details, "- Leak at %s\n", leak)
+		}
+	}
+	if details.Len() > 0 {
+		fmt.Fprintf(&b, "\n## Problems\n%s", details.String())
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func percent(f float64) string {
+	return fmt.Sprintf("%.0f%%", f*100)
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
@@ -0,0 +1,98 @@
+package eval
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// facetKey reduces a skill facet like "time complexity (average vs worst
+// case)" to the words of its name: [time complexity].
+func facetKey(facet string) []string {
+	name, _, _ := strings.Cut(facet, "(")
+	return words(name)
+}
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+func words(s string) []string {
+	var result []string
+	for _, w := range nonWord.Split(strings.ToLower(s), -1) {
+		if len(w) > 2 && w != "and" && w != "the" && w != "vs" {
+			result = append(result, w)
+		}
+	}
+	return result
+}
+
+// coveredFacets returns the skill facets matched by a facet the coach
+// reported. A facet matches when the names share a word, so "complexity"
+// covers "time complexity (average vs worst case)".
+func coveredFacets(skillFacets, probed []string) []string {
+	var covered []string
+	for _, facet := range skillFacets {
+		key := facetKey(facet)
+	match:
+		for _, p := range probed {
+			for _, w := range words(p) {
+				for _, k := range key {
+					if w == k {
+						covered = append(covered, strings.TrimSpace(strings.SplitN(facet, "(", 2)[0]))
+						break match
+					}
+				}
+			}
+		}
+	}
+	return covered
+}
+
+// leakPhrases are ways an interviewer hands over an answer instead of
+// asking for one.
+var leakPhrases = regexp.MustCompile(`(?i)\b(the (correct |right )?answer is|you should use|you'd want to use|you would want to use|a good target (would be|is)|the standard approach is|a common approach is|i would (use|go with|target)|the (main|core) entities are|the requirements (are|would be)|one option is to use)\b`)
+
+// concreteNumber matches figures like 200ms, 10k QPS or 99.9% that a
+// system design candidate is expected to come up with themselves.
+var concreteNumber = regexp.MustCompile(`(?i)\b\d+(?:\.\d+)?\s?(?:k|m|b)?\s?(?:ms|milliseconds|seconds|qps|rps|tps|requests per second|gb|tb|pb|million|billion|%)`)
+
+// findLeaks returns the parts of a system design interviewer's message that
+// give away an answer: telling phrases, and figures the candidate hasn't
+// mentioned.
+func findLeaks(message, candidateSaid string) []string {
+	var leaks []string
+	for _, m := range leakPhrases.FindAllString(message, -1) {
+		leaks = append(leaks, m)
+	}
+	said := strings.ToLower(strings.ReplaceAll(candidateSaid, " ", ""))
+	for _, m := range concreteNumber.FindAllString(message, -1) {
+		if !strings.Contains(said, strings.ToLower(strings.ReplaceAll(m, " ", ""))) {
+			leaks = append(leaks, m)
+		}
+	}
+	return leaks
+}
+
+// correlation is the Pearson correlation of xs and ys, or NaN when either
+// has no variance.
+func correlation(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	var mx, my float64
+	for i := range xs {
+		mx += xs[i] / n
+		my += ys[i] / n
+	}
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
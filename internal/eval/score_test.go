@@ -0,0 +1,71 @@
+package eval
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCoveredFacets(t *testing.T) {
+	facets := []string{
+		"mechanics (how hashing and storage works)",
+		"time complexity (average vs worst case)",
+		"collision handling (chaining vs open addressing)",
+		"trade-offs (when to use hashmap vs tree map vs array)",
+	}
+	got := coveredFacets(facets, []string{"complexity", "Collision-Handling", "mechanics", "complexity"})
+	want := []string{"mechanics", "time complexity", "collision handling"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("covered = %v, want %v", got, want)
+	}
+}
+
+func TestFindLeaks(t *testing.T) {
+	tests := []struct {
+		message, said string
+		want          int
+	}{
+		{"What latency would you target for reads?", "", 0},
+		{"A good target would be 200ms for reads.", "", 2},
+		{"You said 200 ms - why that number?", "I'd aim for 200ms p99", 0},
+		{"Let's aim for 99.9% availability and 10k QPS.", "", 2},
+		{"The main entities are users, posts and follows.", "", 1},
+	}
+	for _, tt := range tests {
+		if got := findLeaks(tt.message, tt.said); len(got) != tt.want {
+			t.Errorf("findLeaks(%q) = %q, want %d leaks", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	if r := correlation([]float64{1, 2, 3, 4}, []float64{1, 2, 3, 4}); math.Abs(r-1) > 1e-9 {
+		t.Errorf("perfect correlation = %v", r)
+	}
+	if r := correlation([]float64{1, 2, 3}, []float64{3, 2, 1}); math.Abs(r+1) > 1e-9 {
+		t.Errorf("inverse correlation = %v", r)
+	}
+	if r := correlation([]float64{1, 1}, []float64{2, 3}); !math.IsNaN(r) {
+		t.Errorf("no variance = %v, want NaN", r)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := summarize([]Drill{
+		{Strength: 1, Turns: 4, Final: true, Rating: 1, MetaClean: 4, FacetCoverage: 0.5},
+		{Strength: 4, Turns: 6, Final: true, Rating: 3, MetaClean: 5, MetaInvalid: 1, FacetCoverage: 1},
+		{Strength: 2, Turns: 21, MetaClean: 21, Domain: "system-design-practical", Leaks: []string{"turn 2: 200ms"}},
+	})
+	if s.FinishRate != 2.0/3 || s.AvgTurnsToFinal != 5 {
+		t.Errorf("finish rate %v, avg turns %v", s.FinishRate, s.AvgTurnsToFinal)
+	}
+	if s.MetaCompliance != 30.0/31 || s.MetaValid != 30.0/31 {
+		t.Errorf("meta compliance %v, valid %v", s.MetaCompliance, s.MetaValid)
+	}
+	if s.RatingCorrelation == nil || *s.RatingCorrelation != 1 || s.RatingError != 0.5 {
+		t.Errorf("rating correlation %v, error %v", s.RatingCorrelation, s.RatingError)
+	}
+	if s.LeakyDrills != 1 || s.PracticalDrills != 1 {
+		t.Errorf("leaks %d of %d", s.LeakyDrills, s.PracticalDrills)
+	}
+}
@@ -0,0 +1,45 @@
+package llm
+
+import (
+	"context"
+
+	"bonk/internal/skills"
+)
+
+// CandidatePromptData is what the candidate template is executed with.
+type CandidatePromptData struct {
+	Skill    *skills.Skill
+	Strength int // 1 (poor) to 4 (excellent), the coach's rating scale
+}
+
+// CandidateReply is a simulated candidate's answer.
+type CandidateReply struct {
+	Text  string
+	Model string
+	Usage Usage
+}
+
+// SimulateCandidate answers the coach's question as a candidate of the given
+// strength, for evaluating the coach. history holds the earlier exchanges.
+func SimulateCandidate(ctx context.Context, skill *skills.Skill, strength int, history []ExchangeData, question string) (*CandidateReply, error) {
+	systemPrompt, _, err := renderPrompt(PromptCandidate, CandidatePromptData{Skill: skill, Strength: strength})
+	if err != nil {
+		return nil, err
+	}
+
+	// The coach is the user here and the candidate the assistant.
+	messages := make([]message, 0, 2*len(history)+1)
+	for _, ex := range history {
+		messages = append(messages,
+			message{Role: "user", Content: ex.Question},
+			message{Role: "assistant", Content: ex.Answer},
+		)
+	}
+	messages = append(messages, message{Role: "user", Content: question})
+
+	r, err := callAPIRaw(ctx, systemPrompt, messages, maxTokens)
+	if err != nil {
+		return nil, err
+	}
+	return &CandidateReply{Text: r.Text, Model: r.Model, Usage: r.Usage}, nil
+}
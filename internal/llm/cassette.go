@@ -0,0 +1,218 @@
+package llm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CassetteMode selects whether API traffic is recorded or replayed.
+type CassetteMode string
+
+const (
+	CassetteOff    CassetteMode = ""
+	CassetteRecord CassetteMode = "record"
+	CassetteReplay CassetteMode = "replay"
+)
+
+// cassetteEntry is one recorded API exchange. The API key is a header and
+// never ends up in the file.
+type cassetteEntry struct {
+	Key      string            `json:"key"`
+	Request  json.RawMessage   `json:"request"`
+	Status   int               `json:"status"`
+	Header   map[string]string `json:"header,omitempty"`
+	Response json.RawMessage   `json:"response"`
+}
+
+type cassetteFile struct {
+	Interactions []cassetteEntry `json:"interactions"`
+}
+
+// recordedHeaders are the response headers worth keeping for replay.
+var recordedHeaders = []string{"Content-Type", "Retry-After"}
+
+// cassette is an http.RoundTripper that records API traffic to a file or
+// answers requests from a previous recording without touching the network.
+type cassette struct {
+	mode CassetteMode
+	path string
+	next http.RoundTripper
+
+	mu      sync.Mutex
+	entries []cassetteEntry
+	used    []bool
+}
+
+var activeCassette *cassette
+
+// errCassetteExhausted means replay has no recorded response left for a
+// request, either because every one was played or because the request
+// changed since recording; retrying can't help.
+var errCassetteExhausted = errors.New("cassette has no unplayed recording of this request")
+
+// UseCassette routes API calls through a cassette file. Record mode starts
+// a fresh cassette at path and saves every exchange as it happens; replay
+// mode loads path and serves responses from it. CassetteOff restores live
+// traffic.
+func UseCassette(mode CassetteMode, path string) error {
+	switch mode {
+	case CassetteOff:
+		activeCassette = nil
+		httpClient.Transport = nil
+		return nil
+	case CassetteRecord, CassetteReplay:
+	default:
+		return fmt.Errorf("unknown cassette mode %q (want record or replay)", mode)
+	}
+	if path == "" {
+		return fmt.Errorf("cassette %s: no file given", mode)
+	}
+
+	c := &cassette{mode: mode, path: path, next: http.DefaultTransport}
+	if mode == CassetteReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read cassette: %w", err)
+		}
+		var f cassetteFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return fmt.Errorf("parse cassette %s: %w", path, err)
+		}
+		c.entries = f.Interactions
+		c.used = make([]bool, len(f.Interactions))
+	}
+
+	activeCassette = c
+	httpClient.Transport = c
+	return nil
+}
+
+func (c *cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	key := requestKey(reqBody)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode == CassetteReplay {
+		e, ok := c.take(key)
+		if !ok {
+			return nil, fmt.Errorf("%s: request %s: %w (re-record the cassette if the prompt changed)", c.path, key, errCassetteExhausted)
+		}
+		return e.response(req), nil
+	}
+
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	e := cassetteEntry{
+		Key:      key,
+		Request:  rawJSON(reqBody),
+		Status:   resp.StatusCode,
+		Response: rawJSON(body),
+	}
+	for _, h := range recordedHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			if e.Header == nil {
+				e.Header = make(map[string]string)
+			}
+			e.Header[h] = v
+		}
+	}
+	c.entries = append(c.entries, e)
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// take returns the first unplayed entry recorded for an identical request.
+// A request that changed since recording matches nothing, so a replay
+// never answers with some other request's response.
+func (c *cassette) take(key string) (cassetteEntry, bool) {
+	for i, e := range c.entries {
+		if !c.used[i] && e.Key == key {
+			c.used[i] = true
+			return e, true
+		}
+	}
+	return cassetteEntry{}, false
+}
+
+func (c *cassette) save() error {
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.entries}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("create cassette dir: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+func (e cassetteEntry) response(req *http.Request) *http.Response {
+	body := []byte(e.Response)
+	// Non-JSON bodies are stored as JSON strings.
+	var s string
+	if json.Unmarshal(body, &s) == nil {
+		body = []byte(s)
+	}
+	header := make(http.Header)
+	for k, v := range e.Header {
+		header.Set(k, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.Status, http.StatusText(e.Status)),
+		StatusCode:    e.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func requestKey(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:8])
+}
+
+// rawJSON keeps JSON bodies readable in the cassette and quotes anything else.
+func rawJSON(body []byte) json.RawMessage {
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
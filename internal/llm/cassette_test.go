@@ -0,0 +1,113 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+// withFakeAPI points the client at handler for the duration of the test.
+func withFakeAPI(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	oldURL, oldKey := apiURL, apiKey
+	apiURL, apiKey = srv.URL, "test-key"
+	t.Cleanup(func() {
+		srv.Close()
+		apiURL, apiKey = oldURL, oldKey
+		UseCassette(CassetteOff, "")
+	})
+}
+
+func TestCassetteRecordReplay(t *testing.T) {
+	calls := 0
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("x-api-key") != "test-key" {
+			t.Errorf("missing api key header")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"content":[{"text":"Question %d\n[meta: facet=basics, type=conceptual, final=false]"}]}`, calls)
+	})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := UseCassette(CassetteRecord, path); err != nil {
+		t.Fatal(err)
+	}
+	first := []message{{Role: "user", Content: "Start the drill."}}
+	second := append(first, message{Role: "assistant", Content: "Question 1"}, message{Role: "user", Content: "answer"})
+	for _, msgs := range [][]message{first, second} {
+		if _, err := askCoach(context.Background(), "system", msgs, ""); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("server saw %d calls while recording, want 2", calls)
+	}
+
+	// Replay out of order with no key: requests are matched by content.
+	apiKey = ""
+	if err := UseCassette(CassetteReplay, path); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := askCoach(context.Background(), "system", second, "")
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if resp.Text != "Question 2" || resp.Facet != "basics" {
+		t.Errorf("replayed %+v, want Question 2 with facet basics", resp)
+	}
+	resp, err = askCoach(context.Background(), "system", first, "")
+	if err != nil {
+		t.Fatalf("replay first: %v", err)
+	}
+	if resp.Text != "Question 1" {
+		t.Errorf("replayed %q, want Question 1", resp.Text)
+	}
+	if calls != 2 {
+		t.Errorf("replay reached the server (%d calls)", calls)
+	}
+	if _, err := askCoach(context.Background(), "system", first, ""); err == nil {
+		t.Error("exhausted cassette still answered")
+	}
+}
+
+func TestCassetteReplayRejectsChangedRequest(t *testing.T) {
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"content":[{"text":"recorded"}]}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := UseCassette(CassetteRecord, path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := callAPIRaw(context.Background(), "old system prompt", []message{{Role: "user", Content: "Start the drill."}}, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UseCassette(CassetteReplay, path); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := callAPIRaw(context.Background(), "new system prompt", []message{{Role: "user", Content: "Start the drill."}}, 100)
+	if !errors.Is(err, errCassetteExhausted) {
+		t.Fatalf("replay with changed prompt = %+v, %v; want errCassetteExhausted", resp, err)
+	}
+	// The unmatched entry is still there for the request it was recorded for.
+	if resp, err := callAPIRaw(context.Background(), "old system prompt", []message{{Role: "user", Content: "Start the drill."}}, 100); err != nil || resp.Text != "recorded" {
+		t.Errorf("replay of the recorded request = %+v, %v", resp, err)
+	}
+}
+
+func TestUseCassetteErrors(t *testing.T) {
+	defer UseCassette(CassetteOff, "")
+	if err := UseCassette("rewind", "x.json"); err == nil {
+		t.Error("accepted unknown mode")
+	}
+	if err := UseCassette(CassetteReplay, filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("replay of a missing cassette succeeded")
+	}
+}
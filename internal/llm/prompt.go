@@ -0,0 +1,140 @@
+package llm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"bonk/internal/skills"
+)
+
+// Prompt templates. Each is embedded from prompts/<name>.tmpl and can be
+// overridden by a file of the same name in the prompt directory.
+const (
+	PromptDrill      = "drill"      // conceptual drills
+	PromptLeetCode   = "leetcode"   // leetcode-patterns
+	PromptPractical  = "practical"  // system-design-practical interviews
+	PromptEstimation = "estimation" // estimation drills
+	PromptFeedback   = "feedback"   // bonk review --feedback
+	PromptCandidate  = "candidate"  // simulated candidate for bonk eval
+)
+
+// PromptNames lists every template.
+var PromptNames = []string{PromptDrill, PromptLeetCode, PromptPractical, PromptEstimation, PromptFeedback, PromptCandidate}
+
+//go:embed prompts/*.tmpl
+var promptFS embed.FS
+
+// promptDir holds template overrides; empty means only the built-in ones.
+var promptDir string
+
+// PromptData is what the coaching templates (drill, leetcode, practical,
+// estimation) are executed with.
+type PromptData struct {
+	Skill      *skills.Skill
+	Guide      string              // reference guide, if the skill has one
+	History    string              // recent sessions of this skill
+	Difficulty string              // easy, medium or hard; empty until there are enough sessions
+	Perf       *PerformanceContext // set whenever Difficulty is
+	Scenario   string              // estimation only: the scenario as given to the candidate
+	Reference  string              // estimation only: reference answers and working
+	Asked      []string            // questions asked in past sessions, one line each
+	Unused     []string            // example problems not used yet; empty until some are
+}
+
+// FeedbackPromptData is what the feedback template is executed with.
+type FeedbackPromptData struct {
+	SkillName string
+}
+
+// PromptInfo describes the template in use for one name.
+type PromptInfo struct {
+	Name    string
+	Version string
+	Path    string // override file, empty for the built-in template
+}
+
+// promptSource returns a template's text, preferring an override.
+func promptSource(name string) ([]byte, string, error) {
+	file := name + ".tmpl"
+	if promptDir != "" {
+		path := filepath.Join(promptDir, file)
+		src, err := os.ReadFile(path)
+		if err == nil {
+			return src, path, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, "", fmt.Errorf("read prompt %s: %w", name, err)
+		}
+	}
+	src, err := DefaultPrompt(name)
+	return src, "", err
+}
+
+// DefaultPrompt returns the built-in text of a template.
+func DefaultPrompt(name string) ([]byte, error) {
+	src, err := promptFS.ReadFile("prompts/" + name + ".tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("unknown prompt %q", name)
+	}
+	return src, nil
+}
+
+// promptVersion identifies a template by name and content, e.g.
+// "drill@3f2a9c01b4de", so a stored session can be traced to its prompt.
+func promptVersion(name string, src []byte) string {
+	sum := sha256.Sum256(src)
+	return name + "@" + hex.EncodeToString(sum[:])[:12]
+}
+
+var promptFuncs = template.FuncMap{
+	// bullets joins items for a "- " list whose first dash is in the template.
+	"bullets": func(items []string) string { return strings.Join(items, "\n- ") },
+}
+
+// renderPrompt executes a template and returns the text and its version.
+func renderPrompt(name string, data any) (string, string, error) {
+	src, path, err := promptSource(name)
+	if err != nil {
+		return "", "", err
+	}
+	where := name
+	if path != "" {
+		where = path
+	}
+	tmpl, err := template.New(name).Funcs(promptFuncs).Option("missingkey=error").Parse(string(src))
+	if err != nil {
+		return "", "", fmt.Errorf("parse prompt %s: %w", where, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("render prompt %s: %w", where, err)
+	}
+	return buf.String(), promptVersion(name, src), nil
+}
+
+// Prompts describes the template in use for each name.
+func Prompts() ([]PromptInfo, error) {
+	infos := make([]PromptInfo, 0, len(PromptNames))
+	for _, name := range PromptNames {
+		src, path, err := promptSource(name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, PromptInfo{Name: name, Version: promptVersion(name, src), Path: path})
+	}
+	return infos, nil
+}
+
+// PromptDir returns the directory searched for template overrides.
+func PromptDir() string {
+	return promptDir
+}
@@ -0,0 +1,87 @@
+package llm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bonk/internal/skills"
+)
+
+func TestBuildSystemPromptRendersEverySkill(t *testing.T) {
+	perf := &PerformanceContext{OverallAvgRating: 3.8, OverallSessions: 5}
+	for _, s := range skills.List() {
+		prompt, version, err := BuildSystemPrompt(s, History{Recent: "history"}, perf)
+		if err != nil {
+			t.Fatalf("%s: %v", s.ID, err)
+		}
+		if !strings.Contains(prompt, s.Name) || strings.Contains(prompt, "<no value>") {
+			t.Errorf("%s: prompt not rendered properly", s.ID)
+		}
+		if !strings.Contains(version, "@") {
+			t.Errorf("%s: version = %q", s.ID, version)
+		}
+	}
+}
+
+func TestPromptOverride(t *testing.T) {
+	dir := t.TempDir()
+	old := promptDir
+	promptDir = dir
+	t.Cleanup(func() { promptDir = old })
+
+	skill := skills.Get("hash-maps")
+	_, builtin, err := BuildSystemPrompt(skill, History{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "drill.tmpl"), []byte("Coach {{.Skill.Name}} gently."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prompt, version, err := BuildSystemPrompt(skill, History{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt != "Coach "+skill.Name+" gently." {
+		t.Errorf("prompt = %q", prompt)
+	}
+	if version == builtin || !strings.HasPrefix(version, "drill@") {
+		t.Errorf("version = %q, built-in %q", version, builtin)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "drill.tmpl"), []byte("{{.Missing}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := BuildSystemPrompt(skill, History{}, nil); err == nil {
+		t.Error("expected an error for a template using an unknown field")
+	}
+}
+
+func TestPromptSteersAwayFromAskedQuestions(t *testing.T) {
+	skill := skills.Get("hash-maps")
+	fresh, _, err := BuildSystemPrompt(skill, History{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(fresh, "Already Asked") || strings.Contains(fresh, "Not used in past sessions") {
+		t.Error("a skill without a question bank should get neither section")
+	}
+
+	prompt, _, err := BuildSystemPrompt(skill, History{
+		Asked:    []string{"[application] Walk me through Two Sum. (problem: Two Sum, asked 3 times)"},
+		Problems: []string{"two sum"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(prompt, "## Already Asked") || !strings.Contains(prompt, "- [application] Walk me through Two Sum.") {
+		t.Error("asked questions missing from prompt")
+	}
+	_, unused, _ := strings.Cut(prompt, "Not used in past sessions yet")
+	unused, _, _ = strings.Cut(unused, "##")
+	if strings.Contains(unused, "Two Sum") || !strings.Contains(unused, "Group Anagrams") {
+		t.Errorf("unused problems = %q", unused)
+	}
+}
@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is a non-200 response from the Messages API.
+type APIError struct {
+	Status     int
+	Body       string
+	RetryAfter time.Duration // from the retry-after header, 0 if absent
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error %d: %s", e.Status, e.Body)
+}
+
+// Retryable reports whether the request may succeed if sent again:
+// rate limits (429), overload (529) and other server errors.
+func (e *APIError) Retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+// Backoff settings; variables so tests can shrink them.
+var (
+	maxAttempts   = 4
+	retryBaseWait = time.Second
+	retryMaxWait  = 30 * time.Second
+)
+
+// withRetry calls fn until it succeeds, fails permanently, runs out of
+// attempts or ctx is done. Waits grow exponentially with jitter, unless the
+// server asked for a specific delay via retry-after.
+func withRetry(ctx context.Context, fn func() ([]byte, error)) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(retryDelay(attempt, lastErr))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		body, err := fn()
+		if err == nil {
+			return body, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+		if errors.Is(err, errCassetteExhausted) {
+			return nil, err
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && !apiErr.Retryable() {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w (gave up after %d attempts)", lastErr, maxAttempts)
+}
+
+// retryDelay picks the wait before the given attempt (1 = first retry).
+func retryDelay(attempt int, lastErr error) time.Duration {
+	var apiErr *APIError
+	if errors.As(lastErr, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, retryMaxWait)
+	}
+	wait := min(retryBaseWait<<(attempt-1), retryMaxWait)
+	// Jitter between half and the full wait so clients don't retry in lockstep.
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// parseRetryAfter reads a retry-after header given in seconds or as an
+// HTTP date.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.ParseFloat(v, 64); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs * float64(time.Second))
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
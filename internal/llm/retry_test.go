@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"bonk/internal/skills"
+)
+
+func withFastRetries(t *testing.T) {
+	t.Helper()
+	oldBase, oldMax := retryBaseWait, retryMaxWait
+	retryBaseWait, retryMaxWait = time.Millisecond, 10*time.Millisecond
+	t.Cleanup(func() { retryBaseWait, retryMaxWait = oldBase, oldMax })
+}
+
+func TestRetryOnOverload(t *testing.T) {
+	withFastRetries(t)
+	calls := 0
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.Header().Set("retry-after", "0")
+			w.WriteHeader(529)
+			fmt.Fprint(w, `{"type":"error","error":{"type":"overloaded_error"}}`)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			fmt.Fprint(w, `{"content":[{"text":"ok"}]}`)
+		}
+	})
+
+	r, err := callAPIRaw(context.Background(), "system", []message{{Role: "user", Content: "hi"}}, 10)
+	if err != nil {
+		t.Fatalf("callAPIRaw: %v", err)
+	}
+	if r.Text != "ok" || calls != 3 {
+		t.Errorf("got %q after %d calls, want ok after 3", r.Text, calls)
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	withFastRetries(t)
+	calls := 0
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := callAPIRaw(context.Background(), "system", nil, 10)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest {
+		t.Fatalf("err = %v, want 400 APIError", err)
+	}
+	if calls != 1 {
+		t.Errorf("400 was retried (%d calls)", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	withFastRetries(t)
+	calls := 0
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	if _, err := callAPIRaw(context.Background(), "system", nil, 10); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != maxAttempts {
+		t.Errorf("made %d calls, want %d", calls, maxAttempts)
+	}
+}
+
+func TestSendCancelledLeavesConversationUntouched(t *testing.T) {
+	block := make(chan struct{})
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	})
+	defer close(block)
+
+	conv, err := NewConversation(skills.Get("hash-maps"), History{}, nil, 20, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	if _, err := conv.Send(ctx, "my answer"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if conv.turn != 0 || len(conv.messages) != 1 {
+		t.Errorf("cancelled send changed conversation: turn %d, %d messages", conv.turn, len(conv.messages))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"7", 7 * time.Second},
+		{"0.5", 500 * time.Millisecond},
+		{"-1", 0},
+		{now.Add(10 * time.Second).Format(http.TimeFormat), 10 * time.Second},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.in, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
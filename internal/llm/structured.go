@@ -0,0 +1,261 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"input_schema"`
+}
+
+type toolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+// coachTool is how the coach replies: the message for the candidate plus
+// the turn's metadata as typed fields, instead of a trailer in the text.
+var coachTool = tool{
+	Name:        "coach_reply",
+	Description: "Send your reply to the candidate together with metadata about this turn. Call it on every turn.",
+	InputSchema: json.RawMessage(`{
+  "type": "object",
+  "properties": {
+    "message": {"type": "string", "description": "Everything you say to the candidate, in markdown."},
+    "facet": {"type": "string", "description": "Short name of the facet or area this turn probes."},
+    "type": {"type": "string", "enum": ["conceptual", "problem", "interview"]},
+    "final": {"type": "boolean", "description": "True only when this message is the final assessment."},
+    "rating": {"type": "integer", "minimum": 1, "maximum": 4, "description": "Your current assessment: 1=poor, 2=shaky, 3=solid, 4=excellent."},
+    "problem": {"type": "string", "description": "Name of the concrete problem or scenario the question is built on, e.g. an example problem. Omit for purely conceptual questions."},
+    "phase": {"type": "string", "enum": ["requirements", "entities", "api", "dataflow", "highlevel", "deepdives"], "description": "Current interview phase (system design practical only)."},
+    "phase_scores": {
+      "type": "object",
+      "description": "System design practical final assessment only: a 1-4 score for each phase covered, keyed by phase.",
+      "additionalProperties": {"type": "integer", "minimum": 1, "maximum": 4}
+    }
+  },
+  "required": ["message", "facet", "type", "final", "rating"]
+}`),
+}
+
+// practicalPhases are the interview phases of system-design-practical, in order.
+var practicalPhases = []string{"requirements", "entities", "api", "dataflow", "highlevel", "deepdives"}
+
+var questionTypes = []string{"conceptual", "problem", "interview"}
+
+// maxMetaRetries is how many times the coach is asked to fix a reply whose
+// metadata is missing or invalid before the reply is used as-is.
+var maxMetaRetries = 2
+
+// askCoach asks for the coach's next turn through coachTool, validating the
+// metadata and re-prompting when it is missing or invalid.
+func askCoach(ctx context.Context, systemPrompt string, messages []message, domain string) (*Response, error) {
+	var usage Usage
+	for attempt := 0; ; attempt++ {
+		r, err := send(ctx, apiRequest{
+			Model:      model,
+			MaxTokens:  maxTokens,
+			System:     systemPrompt,
+			Messages:   messages,
+			Tools:      []tool{coachTool},
+			ToolChoice: &toolChoice{Type: "tool", Name: coachTool.Name},
+		})
+		if err != nil {
+			return nil, err
+		}
+		usage = usage.Add(r.Usage)
+
+		resp, issues := parseCoachReply(r, domain)
+		resp.Model = r.Model
+		resp.Usage = usage
+		resp.Reprompts = attempt
+		if len(issues) == 0 || attempt >= maxMetaRetries {
+			resp.MetaIssues = issues
+			return resp, nil
+		}
+
+		// Show the coach its reply and what was wrong with it, without
+		// touching the conversation itself.
+		shown := resp.Text
+		if shown == "" {
+			shown = "(empty message)"
+		}
+		messages = append(messages[:len(messages):len(messages)],
+			message{Role: "assistant", Content: shown},
+			message{Role: "user", Content: fmt.Sprintf(
+				"[System: that reply had invalid metadata: %s. Call %s again with the same message and valid fields.]",
+				strings.Join(issues, "; "), coachTool.Name)},
+		)
+	}
+}
+
+// parseCoachReply turns an API reply into a Response, preferring the
+// coach_reply tool call and falling back to a [meta: ...] trailer in the
+// text. It returns the problems found with the metadata.
+func parseCoachReply(r reply, domain string) (*Response, []string) {
+	if r.ToolName == coachTool.Name {
+		var input map[string]any
+		if err := json.Unmarshal(r.ToolInput, &input); err == nil {
+			fields := make(map[string]string, len(input))
+			for k, v := range input {
+				fields[strings.ToLower(k)] = fieldString(v)
+			}
+			text := fields["message"]
+			if text == "" {
+				// Some replies put the message in a text block instead.
+				text = r.Text
+			}
+			return buildResponse(strings.TrimSpace(text), fields, domain)
+		}
+		if r.StopReason == "max_tokens" {
+			return &Response{Text: r.Text}, []string{"reply was cut off at max_tokens"}
+		}
+	}
+	return parseResponse(r.Text, domain)
+}
+
+// metaTrailer matches the last "[meta: ...]" block, tolerating a missing
+// closing bracket at the end of the text.
+var metaTrailer = regexp.MustCompile(`(?is)\[\s*meta\s*:([^\]]*)(?:\]|$)`)
+
+// parseResponse reads metadata from a [meta: key=value, ...] trailer in any
+// field order, with "=" or ":" separators and "," or ";" between fields.
+func parseResponse(text, domain string) (*Response, []string) {
+	locs := metaTrailer.FindAllStringSubmatchIndex(text, -1)
+	if locs == nil {
+		resp := &Response{Text: strings.TrimSpace(text)}
+		return resp, []string{"no metadata (call " + coachTool.Name + ")"}
+	}
+	loc := locs[len(locs)-1]
+	fields := make(map[string]string)
+	for _, part := range strings.FieldsFunc(text[loc[2]:loc[3]], func(r rune) bool { return r == ',' || r == ';' }) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			key, value, ok = strings.Cut(part, ":")
+		}
+		if !ok {
+			continue
+		}
+		fields[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	stripped := strings.TrimSpace(text[:loc[0]] + text[loc[1]:])
+	return buildResponse(stripped, fields, domain)
+}
+
+// buildResponse validates metadata fields into a Response. Invalid fields
+// are left at their zero value and reported.
+func buildResponse(text string, fields map[string]string, domain string) (*Response, []string) {
+	resp := &Response{Text: text}
+	var issues []string
+
+	if text == "" {
+		issues = append(issues, "empty message")
+	}
+
+	resp.Facet = strings.TrimSpace(fields["facet"])
+	if resp.Facet == "" {
+		issues = append(issues, "missing facet")
+	}
+
+	resp.QuestionType = strings.ToLower(strings.TrimSpace(fields["type"]))
+	if !slices.Contains(questionTypes, resp.QuestionType) {
+		issues = append(issues, fmt.Sprintf("type %q is not one of %s", fields["type"], strings.Join(questionTypes, ", ")))
+		resp.QuestionType = ""
+	}
+
+	switch strings.ToLower(strings.TrimSpace(fields["final"])) {
+	case "true", "yes":
+		resp.IsFinal = true
+	case "false", "no":
+	default:
+		issues = append(issues, fmt.Sprintf("final %q is not true or false", fields["final"]))
+	}
+
+	// Rating is asked for every turn but only required on the final one.
+	if raw := strings.TrimSpace(fields["rating"]); raw != "" {
+		// Accept "3" as well as "3/4".
+		if n, err := strconv.Atoi(strings.SplitN(raw, "/", 2)[0]); err == nil && n >= 1 && n <= 4 {
+			resp.LLMRating = n
+		} else {
+			issues = append(issues, fmt.Sprintf("rating %q is not 1-4", raw))
+		}
+	} else if resp.IsFinal {
+		issues = append(issues, "final assessment has no rating")
+	}
+
+	resp.Problem = strings.TrimSpace(fields["problem"])
+
+	if raw := strings.ToLower(strings.TrimSpace(fields["phase"])); raw != "" {
+		if slices.Contains(practicalPhases, raw) {
+			resp.Phase = raw
+		} else if domain == "system-design-practical" {
+			issues = append(issues, fmt.Sprintf("phase %q is not one of %s", raw, strings.Join(practicalPhases, ", ")))
+		}
+	} else if domain == "system-design-practical" {
+		issues = append(issues, "missing phase")
+	}
+
+	if raw := strings.TrimSpace(fields["phase_scores"]); raw != "" {
+		scores, bad := parsePhaseScores(raw)
+		resp.PhaseScores = scores
+		issues = append(issues, bad...)
+	} else if resp.IsFinal && domain == "system-design-practical" {
+		issues = append(issues, "final assessment has no phase_scores")
+	}
+
+	if resp.IsFinal {
+		resp.Assessment = resp.Text
+	}
+	return resp, issues
+}
+
+// parsePhaseScores reads a phase_scores object, keeping the valid entries.
+func parsePhaseScores(raw string) (map[string]int, []string) {
+	var decoded map[string]any
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return nil, []string{fmt.Sprintf("phase_scores %q is not an object", raw)}
+	}
+	scores := make(map[string]int)
+	var issues []string
+	for phase, v := range decoded {
+		phase = strings.ToLower(strings.TrimSpace(phase))
+		n, err := strconv.Atoi(fieldString(v))
+		switch {
+		case !slices.Contains(practicalPhases, phase):
+			issues = append(issues, fmt.Sprintf("phase_scores has unknown phase %q", phase))
+		case err != nil || n < 1 || n > 4:
+			issues = append(issues, fmt.Sprintf("phase_scores[%s] %v is not 1-4", phase, v))
+		default:
+			scores[phase] = n
+		}
+	}
+	sort.Strings(issues)
+	return scores, issues
+}
+
+// fieldString renders a decoded JSON value the way it would appear in a
+// [meta: ...] trailer, so both paths share one validator.
+func fieldString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
@@ -0,0 +1,284 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"testing"
+
+	"bonk/internal/estimate"
+	"bonk/internal/skills"
+)
+
+func TestParseResponseTolerant(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		domain string
+		want   Response
+		issues int
+	}{
+		{
+			name: "canonical",
+			text: "What is a hash?\n[meta: facet=mechanics, type=conceptual, final=false, rating=3]",
+			want: Response{Text: "What is a hash?", Facet: "mechanics", QuestionType: "conceptual", LLMRating: 3},
+		},
+		{
+			name: "reordered with colons and semicolons",
+			text: "Good work.\n[META: final: true; rating: 4/4; type: Problem; facet: complexity]",
+			want: Response{Text: "Good work.", Facet: "complexity", QuestionType: "problem", IsFinal: true, LLMRating: 4, Assessment: "Good work."},
+		},
+		{
+			name:   "unterminated trailer with phase",
+			text:   "Let's talk APIs.\n[meta: phase=api, facet=api-design, type=interview, final=false, rating=2",
+			domain: "system-design-practical",
+			want:   Response{Text: "Let's talk APIs.", Facet: "api-design", QuestionType: "interview", LLMRating: 2, Phase: "api"},
+		},
+		{
+			name:   "missing trailer",
+			text:   "Just a question?",
+			want:   Response{Text: "Just a question?"},
+			issues: 1,
+		},
+		{
+			name:   "final without rating, bad type",
+			text:   "Done.\n[meta: facet=x, type=quiz, final=true]",
+			want:   Response{Text: "Done.", Facet: "x", IsFinal: true, Assessment: "Done."},
+			issues: 2,
+		},
+		{
+			name:   "practical needs a phase",
+			text:   "Scale it.\n[meta: facet=scaling, type=interview, final=false, rating=3]",
+			domain: "system-design-practical",
+			want:   Response{Text: "Scale it.", Facet: "scaling", QuestionType: "interview", LLMRating: 3},
+			issues: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, issues := parseResponse(tt.text, tt.domain)
+			if len(issues) != tt.issues {
+				t.Errorf("issues = %q, want %d", issues, tt.issues)
+			}
+			if got.Text != tt.want.Text || got.Facet != tt.want.Facet || got.QuestionType != tt.want.QuestionType ||
+				got.IsFinal != tt.want.IsFinal || got.LLMRating != tt.want.LLMRating || got.Phase != tt.want.Phase ||
+				got.Assessment != tt.want.Assessment {
+				t.Errorf("got %+v\nwant %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAskCoachUsesToolAndReprompts(t *testing.T) {
+	var requests []apiRequest
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req apiRequest
+		json.Unmarshal(body, &req)
+		requests = append(requests, req)
+
+		if len(requests) == 1 {
+			// Forgot the metadata entirely.
+			fmt.Fprint(w, `{"content":[{"type":"text","text":"Nice. Now, how would you shard it?"}],"usage":{"input_tokens":10,"output_tokens":5}}`)
+			return
+		}
+		fmt.Fprint(w, `{"content":[{"type":"tool_use","id":"t1","name":"coach_reply","input":
+			{"message":"Nice. Now, how would you shard it?","phase":"deepdives","rating":3,"final":false,"type":"interview","facet":"sharding"}}],
+			"usage":{"input_tokens":20,"output_tokens":7}}`)
+	})
+
+	msgs := []message{{Role: "user", Content: "Start the drill."}}
+	resp, err := askCoach(context.Background(), "system", msgs, "system-design-practical")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || resp.Reprompts != 1 || len(resp.MetaIssues) != 0 {
+		t.Fatalf("%d requests, %d reprompts, issues %q", len(requests), resp.Reprompts, resp.MetaIssues)
+	}
+	if resp.Text != "Nice. Now, how would you shard it?" || resp.Phase != "deepdives" || resp.LLMRating != 3 || resp.Facet != "sharding" {
+		t.Errorf("got %+v", *resp)
+	}
+	if resp.Usage.InputTokens != 30 || resp.Usage.OutputTokens != 12 {
+		t.Errorf("usage %+v not summed over the re-prompt", resp.Usage)
+	}
+
+	first := requests[0]
+	if first.ToolChoice == nil || first.ToolChoice.Name != "coach_reply" || len(first.Tools) != 1 {
+		t.Errorf("coach_reply tool not forced: %+v", first.ToolChoice)
+	}
+	retry := requests[1].Messages
+	if len(retry) != 3 || retry[1].Role != "assistant" || !strings.Contains(retry[2].Content, "invalid metadata") {
+		t.Errorf("re-prompt messages = %+v", retry)
+	}
+	if len(msgs) != 1 {
+		t.Errorf("re-prompt modified the caller's messages")
+	}
+}
+
+func TestAskCoachGivesUpAfterRetries(t *testing.T) {
+	calls := 0
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"content":[{"type":"text","text":"no metadata here"}]}`)
+	})
+
+	resp, err := askCoach(context.Background(), "system", []message{{Role: "user", Content: "hi"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != maxMetaRetries+1 {
+		t.Errorf("%d calls, want %d", calls, maxMetaRetries+1)
+	}
+	if resp.Text != "no metadata here" || len(resp.MetaIssues) == 0 {
+		t.Errorf("got %+v", *resp)
+	}
+}
+
+func TestParseCoachReplyPhaseScores(t *testing.T) {
+	input := `{"message": "Solid interview.", "facet": "overall", "type": "interview", "final": true, "rating": 3,
+		"phase": "deepdives", "phase_scores": {"requirements": 4, "api": 2, "highlevel": 7, "vibes": 3}}`
+	resp, issues := parseCoachReply(reply{ToolName: coachTool.Name, ToolInput: json.RawMessage(input)}, "system-design-practical")
+	if want := map[string]int{"requirements": 4, "api": 2}; fmt.Sprint(resp.PhaseScores) != fmt.Sprint(want) {
+		t.Errorf("scores = %v, want %v", resp.PhaseScores, want)
+	}
+	if len(issues) != 2 {
+		t.Errorf("issues = %q, want the bad score and the unknown phase", issues)
+	}
+
+	input = `{"message": "Done.", "facet": "overall", "type": "interview", "final": true, "rating": 3, "phase": "deepdives"}`
+	if _, issues := parseCoachReply(reply{ToolName: coachTool.Name, ToolInput: json.RawMessage(input)}, "system-design-practical"); len(issues) != 1 {
+		t.Errorf("issues = %q, want missing phase_scores", issues)
+	}
+}
+
+func TestConversationSendsCurrentNotesOnly(t *testing.T) {
+	var last apiRequest
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &last)
+		fmt.Fprint(w, `{"content":[{"type":"tool_use","name":"coach_reply","input":
+			{"message":"Next?","phase":"api","rating":2,"final":false,"type":"interview","facet":"api"}}]}`)
+	})
+
+	conv, err := NewConversation(skills.Get("design-twitter"), History{}, nil, 40, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := conv.Send(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	conv.SetNotes("v1")
+	if _, err := conv.Send(ctx, "first answer"); err != nil {
+		t.Fatal(err)
+	}
+	conv.SetNotes("v2")
+	if _, err := conv.Send(ctx, "second answer"); err != nil {
+		t.Fatal(err)
+	}
+
+	var withNotes int
+	for _, m := range last.Messages {
+		if strings.Contains(m.Content, "[Design notebook]") {
+			withNotes++
+		}
+	}
+	final := last.Messages[len(last.Messages)-1].Content
+	if withNotes != 1 || !strings.HasSuffix(final, "[Design notebook]\nv2") || strings.Contains(last.Messages[2].Content, "v1") {
+		t.Errorf("messages = %+v", last.Messages)
+	}
+}
+
+func TestEstimationAnswersAreChecked(t *testing.T) {
+	var last apiRequest
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &last)
+		fmt.Fprint(w, `{"content":[{"type":"tool_use","name":"coach_reply","input":
+			{"message":"Next step?","rating":3,"final":false,"type":"problem","facet":"peak"}}]}`)
+	})
+
+	conv, err := NewConversation(skills.Get("estimate-qps"), History{}, nil, 20, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(conv.SystemPrompt(), "average write QPS ≈") {
+		t.Fatalf("system prompt has no reference answers:\n%s", conv.SystemPrompt())
+	}
+	ctx := context.Background()
+	if _, err := conv.Send(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	step := conv.Estimate().Scenario.Steps[0]
+	resp, err := conv.Send(ctx, "so about "+estimate.Format(step.Value, step.Unit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Check == nil || resp.Check.Verdict != estimate.Close || len(conv.Estimate().Results) != 1 {
+		t.Fatalf("check = %+v", resp.Check)
+	}
+	if got := last.Messages[len(last.Messages)-1].Content; !strings.Contains(got, "[bonk check] Step 1 of 2") {
+		t.Errorf("answer sent without the check: %q", got)
+	}
+}
+
+func TestHintsEscalateUntilAnswered(t *testing.T) {
+	var last apiRequest
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &last)
+		fmt.Fprint(w, `{"content":[{"type":"tool_use","name":"coach_reply","input":
+			{"message":"Think about lookups.","rating":2,"final":false,"type":"problem","facet":"application"}}]}`)
+	})
+
+	conv, err := NewConversation(skills.Get("hash-maps"), History{}, nil, 20, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := conv.Send(ctx, ""); err != nil {
+		t.Fatal(err)
+	}
+	for level := 1; level <= MaxHints; level++ {
+		resp, err := conv.Hint(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		asked := last.Messages[len(last.Messages)-1].Content
+		if resp.Hint != level || !strings.Contains(asked, fmt.Sprintf("hint %d of %d (%s)", level, MaxHints, HintLevels[level-1])) {
+			t.Fatalf("hint %d: resp.Hint = %d, request %q", level, resp.Hint, asked)
+		}
+	}
+	if _, err := conv.Hint(ctx); err == nil {
+		t.Error("expected an error after the last hint")
+	}
+
+	if _, err := conv.Reveal(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(last.Messages[len(last.Messages)-1].Content, "gave up") || conv.Hints() != 0 {
+		t.Errorf("reveal: hints = %d, messages = %+v", conv.Hints(), last.Messages)
+	}
+	if _, err := conv.Hint(ctx); err != nil {
+		t.Errorf("the next question should get hints again: %v", err)
+	}
+}
+
+func TestSeededConversationRepeatsScenario(t *testing.T) {
+	skill := skills.Get("estimate-qps")
+	a, err := NewConversation(skill, History{}, nil, 20, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewConversation(skill, History{}, nil, 20, rand.New(rand.NewSource(7)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.SystemPrompt() != b.SystemPrompt() {
+		t.Error("the same seed produced different scenarios")
+	}
+}
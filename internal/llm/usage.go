@@ -0,0 +1,69 @@
+package llm
+
+import "strings"
+
+// Usage is the token accounting the API returns with every message.
+type Usage struct {
+	InputTokens      int `json:"input_tokens"`
+	OutputTokens     int `json:"output_tokens"`
+	CacheWriteTokens int `json:"cache_creation_input_tokens"`
+	CacheReadTokens  int `json:"cache_read_input_tokens"`
+}
+
+// Total counts every token billed for the call.
+func (u Usage) Total() int {
+	return u.InputTokens + u.OutputTokens + u.CacheWriteTokens + u.CacheReadTokens
+}
+
+// Add returns the sum of two usages.
+func (u Usage) Add(o Usage) Usage {
+	return Usage{
+		InputTokens:      u.InputTokens + o.InputTokens,
+		OutputTokens:     u.OutputTokens + o.OutputTokens,
+		CacheWriteTokens: u.CacheWriteTokens + o.CacheWriteTokens,
+		CacheReadTokens:  u.CacheReadTokens + o.CacheReadTokens,
+	}
+}
+
+// Price is a model's list price in USD per million tokens.
+type Price struct {
+	Input, Output, CacheWrite, CacheRead float64
+}
+
+// prices are matched by model-name prefix, most specific first.
+var prices = []struct {
+	prefix string
+	price  Price
+}{
+	{"claude-opus-4", Price{15, 75, 18.75, 1.50}},
+	{"claude-sonnet-4", Price{3, 15, 3.75, 0.30}},
+	{"claude-3-7-sonnet", Price{3, 15, 3.75, 0.30}},
+	{"claude-3-5-sonnet", Price{3, 15, 3.75, 0.30}},
+	{"claude-haiku-4", Price{1, 5, 1.25, 0.10}},
+	{"claude-3-5-haiku", Price{0.80, 4, 1, 0.08}},
+	{"claude-3-haiku", Price{0.25, 1.25, 0.30, 0.03}},
+}
+
+// PriceFor looks up the list price of a model.
+func PriceFor(model string) (Price, bool) {
+	for _, p := range prices {
+		if strings.HasPrefix(model, p.prefix) {
+			return p.price, true
+		}
+	}
+	return Price{}, false
+}
+
+// Cost estimates the dollar cost of usage on model. The second result is
+// false when the model's price is unknown.
+func Cost(model string, u Usage) (float64, bool) {
+	p, ok := PriceFor(model)
+	if !ok {
+		return 0, false
+	}
+	cost := float64(u.InputTokens)*p.Input +
+		float64(u.OutputTokens)*p.Output +
+		float64(u.CacheWriteTokens)*p.CacheWrite +
+		float64(u.CacheReadTokens)*p.CacheRead
+	return cost / 1e6, true
+}
@@ -0,0 +1,39 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+)
+
+func TestCallAPIReportsUsage(t *testing.T) {
+	withFakeAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"model":"claude-sonnet-4-20250514","content":[{"text":"hi"}],
+			"usage":{"input_tokens":1200,"output_tokens":300,"cache_creation_input_tokens":50,"cache_read_input_tokens":7}}`)
+	})
+
+	resp, err := callAPIRaw(context.Background(), "system", []message{{Role: "user", Content: "Start the drill."}}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Usage{InputTokens: 1200, OutputTokens: 300, CacheWriteTokens: 50, CacheReadTokens: 7}
+	if resp.Usage != want || resp.Model != "claude-sonnet-4-20250514" {
+		t.Errorf("got %+v from %q, want %+v", resp.Usage, resp.Model, want)
+	}
+	if resp.Usage.Total() != 1557 {
+		t.Errorf("Total() = %d, want 1557", resp.Usage.Total())
+	}
+}
+
+func TestCost(t *testing.T) {
+	u := Usage{InputTokens: 1_000_000, OutputTokens: 100_000}
+	cost, ok := Cost("claude-sonnet-4-20250514", u)
+	if !ok || math.Abs(cost-4.5) > 1e-9 {
+		t.Errorf("sonnet cost = %v (%v), want 4.5", cost, ok)
+	}
+	if _, ok := Cost("some-future-model", u); ok {
+		t.Error("unknown model has a price")
+	}
+}
@@ -0,0 +1,84 @@
+// Package notebook is the design doc a candidate keeps during a system
+// design practical interview, one section per interview phase.
+package notebook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"bonk/internal/pacing"
+)
+
+// Doc holds the notes for each phase, keyed by phase ID.
+type Doc struct {
+	Sections map[string]string `json:"sections"`
+}
+
+// New returns an empty doc.
+func New() *Doc {
+	return &Doc{Sections: make(map[string]string)}
+}
+
+// Parse reads a doc saved by Marshal. An empty string is an empty doc.
+func Parse(s string) (*Doc, error) {
+	d := New()
+	if strings.TrimSpace(s) == "" {
+		return d, nil
+	}
+	if err := json.Unmarshal([]byte(s), d); err != nil {
+		return nil, fmt.Errorf("parse design doc: %w", err)
+	}
+	if d.Sections == nil {
+		d.Sections = make(map[string]string)
+	}
+	return d, nil
+}
+
+// Marshal encodes the doc for storage.
+func (d *Doc) Marshal() string {
+	b, _ := json.Marshal(d)
+	return string(b)
+}
+
+// Set replaces a section's notes.
+func (d *Doc) Set(phase, text string) {
+	if strings.TrimSpace(text) == "" {
+		delete(d.Sections, phase)
+		return
+	}
+	d.Sections[phase] = text
+}
+
+// Get returns a section's notes.
+func (d *Doc) Get(phase string) string {
+	return d.Sections[phase]
+}
+
+// Empty reports whether no section has notes.
+func (d *Doc) Empty() bool {
+	return len(d.Sections) == 0
+}
+
+// Context renders the notes for the coach, in phase order.
+func (d *Doc) Context() string {
+	var b strings.Builder
+	for _, p := range pacing.Phases {
+		if text := strings.TrimSpace(d.Sections[p.ID]); text != "" {
+			fmt.Fprintf(&b, "%s:\n%s\n\n", p.Title, text)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Markdown renders the notes as a design document under the given title.
+func (d *Doc) Markdown(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+	for _, p := range pacing.Phases {
+		if text := strings.TrimSpace(d.Sections[p.ID]); text != "" {
+			fmt.Fprintf(&b, "\n## %s\n\n%s\n", p.Title, text)
+		}
+	}
+	return b.String()
+}
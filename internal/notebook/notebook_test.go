@@ -0,0 +1,44 @@
+package notebook
+
+import "testing"
+
+func TestDocRoundTripAndMarkdown(t *testing.T) {
+	d := New()
+	d.Set("api", "POST /urls -> {short}\nGET /{short} -> 302")
+	d.Set("requirements", "- shorten a URL\n- redirect")
+	d.Set("entities", "   ")
+
+	parsed, err := Parse(d.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# URL Shortener
+
+## Requirements
+
+- shorten a URL
+- redirect
+
+## API Design
+
+POST /urls -> {short}
+GET /{short} -> 302
+`
+	if got := parsed.Markdown("URL Shortener"); got != want {
+		t.Errorf("markdown =\n%s\nwant\n%s", got, want)
+	}
+	if got := parsed.Context(); got != "Requirements:\n- shorten a URL\n- redirect\n\nAPI Design:\nPOST /urls -> {short}\nGET /{short} -> 302" {
+		t.Errorf("context = %q", got)
+	}
+}
+
+func TestParseEmpty(t *testing.T) {
+	d, err := Parse("")
+	if err != nil || !d.Empty() {
+		t.Fatalf("Parse(\"\") = %v, %v", d, err)
+	}
+	d.Set("api", "x")
+	if d.Empty() {
+		t.Error("doc with a section reports empty")
+	}
+}
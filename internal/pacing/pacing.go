@@ -0,0 +1,151 @@
+// Package pacing tracks time and turns per phase of a system design
+// practical interview and flags phases that ran long or were skipped.
+package pacing
+
+import (
+	"fmt"
+	"time"
+)
+
+// Phase is a stage of the interview framework.
+type Phase struct {
+	ID       string // as reported by the coach
+	Title    string
+	Budget   time.Duration // time a 45-minute interview can afford
+	Optional bool          // fine to skip
+}
+
+// Phases are the interview phases in order.
+var Phases = []Phase{
+	{ID: "requirements", Title: "Requirements", Budget: 5 * time.Minute},
+	{ID: "entities", Title: "Core Entities", Budget: 2 * time.Minute},
+	{ID: "api", Title: "API Design", Budget: 5 * time.Minute},
+	{ID: "dataflow", Title: "Data Flow", Budget: 5 * time.Minute, Optional: true},
+	{ID: "highlevel", Title: "High-Level Design", Budget: 15 * time.Minute},
+	{ID: "deepdives", Title: "Deep Dives", Budget: 10 * time.Minute},
+}
+
+// Lookup returns the phase with the given ID and its 1-based position.
+func Lookup(id string) (Phase, int, bool) {
+	for i, p := range Phases {
+		if p.ID == id {
+			return p, i + 1, true
+		}
+	}
+	return Phase{}, 0, false
+}
+
+// Title returns a phase's display name, or the ID if it is unknown.
+func Title(id string) string {
+	if p, _, ok := Lookup(id); ok {
+		return p.Title
+	}
+	return id
+}
+
+// Stat is the time and turns spent in one phase, and the coach's 1-4
+// score for it (0 if none).
+type Stat struct {
+	Phase    string
+	Turns    int
+	Duration time.Duration
+	Score    int
+}
+
+// Tracker attributes wall-clock time and coach turns to phases. Time runs
+// from the coach's first question in a phase until it moves to another.
+type Tracker struct {
+	stats   []Stat
+	current int // index into stats, -1 before the first phase
+	since   time.Time
+}
+
+// NewTracker returns an empty tracker.
+func NewTracker() *Tracker {
+	return &Tracker{current: -1}
+}
+
+// Observe records a coach turn in the given phase at time t. Turns without
+// a phase count toward the current one.
+func (t *Tracker) Observe(phase string, at time.Time) {
+	if phase != "" && (t.current < 0 || t.stats[t.current].Phase != phase) {
+		t.stop(at)
+		t.current = t.index(phase)
+		t.since = at
+	}
+	if t.current >= 0 {
+		t.stats[t.current].Turns++
+	}
+}
+
+// Finish stops the clock on the current phase at time t.
+func (t *Tracker) Finish(at time.Time) {
+	t.stop(at)
+	t.current = -1
+}
+
+// Stats returns the phases in the order they were first entered, with the
+// current one counted up to now.
+func (t *Tracker) Stats(now time.Time) []Stat {
+	stats := append([]Stat(nil), t.stats...)
+	if t.current >= 0 {
+		stats[t.current].Duration += now.Sub(t.since)
+	}
+	return stats
+}
+
+func (t *Tracker) stop(at time.Time) {
+	if t.current >= 0 {
+		t.stats[t.current].Duration += at.Sub(t.since)
+	}
+}
+
+func (t *Tracker) index(phase string) int {
+	for i, s := range t.stats {
+		if s.Phase == phase {
+			return i
+		}
+	}
+	t.stats = append(t.stats, Stat{Phase: phase})
+	return len(t.stats) - 1
+}
+
+// Warnings describes pacing problems: phases that took more than half again
+// their budget, and required phases never reached.
+func Warnings(stats []Stat) []string {
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, s := range stats {
+		seen[s.Phase] = true
+		p, _, ok := Lookup(s.Phase)
+		if !ok {
+			continue
+		}
+		if s.Duration > p.Budget*3/2 {
+			warnings = append(warnings, fmt.Sprintf("%s on %s (budget %s)",
+				FormatDuration(s.Duration), p.Title, FormatDuration(p.Budget)))
+		}
+	}
+	if len(stats) == 0 {
+		return warnings
+	}
+	for _, p := range Phases {
+		if !p.Optional && !seen[p.ID] {
+			warnings = append(warnings, "never reached "+p.Title)
+		}
+	}
+	return warnings
+}
+
+// FormatDuration renders a duration in whole minutes, or seconds under a
+// minute: "12 minutes", "1 minute", "40s".
+func FormatDuration(d time.Duration) string {
+	switch m := int(d.Round(time.Minute) / time.Minute); {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Round(time.Second)/time.Second))
+	case m == 1:
+		return "1 minute"
+	default:
+		return fmt.Sprintf("%d minutes", m)
+	}
+}
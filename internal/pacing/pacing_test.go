@@ -0,0 +1,76 @@
+package pacing
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTracker(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	at := func(min int) time.Time { return start.Add(time.Duration(min) * time.Minute) }
+
+	tr := NewTracker()
+	tr.Observe("requirements", at(0))
+	tr.Observe("requirements", at(4))
+	tr.Observe("", at(8)) // no phase: still requirements
+	tr.Observe("api", at(12))
+	tr.Observe("requirements", at(15)) // back for a clarification
+	tr.Observe("highlevel", at(17))
+	tr.Finish(at(30))
+
+	want := []Stat{
+		{Phase: "requirements", Turns: 4, Duration: 14 * time.Minute},
+		{Phase: "api", Turns: 1, Duration: 3 * time.Minute},
+		{Phase: "highlevel", Turns: 1, Duration: 13 * time.Minute},
+	}
+	if got := tr.Stats(at(40)); !reflect.DeepEqual(got, want) {
+		t.Errorf("stats = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestTrackerCountsCurrentPhaseToNow(t *testing.T) {
+	start := time.Now()
+	tr := NewTracker()
+	tr.Observe("entities", start)
+	stats := tr.Stats(start.Add(90 * time.Second))
+	if len(stats) != 1 || stats[0].Duration != 90*time.Second {
+		t.Errorf("stats = %+v", stats)
+	}
+	// Stats must not move the clock.
+	if again := tr.Stats(start.Add(90 * time.Second)); again[0].Duration != 90*time.Second {
+		t.Errorf("second call = %+v", again)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	stats := []Stat{
+		{Phase: "requirements", Duration: 12 * time.Minute},
+		{Phase: "entities", Duration: 2 * time.Minute},
+		{Phase: "api", Duration: 7 * time.Minute}, // within half again the budget
+		{Phase: "highlevel", Duration: 20 * time.Minute},
+	}
+	want := []string{
+		"12 minutes on Requirements (budget 5 minutes)",
+		"never reached Deep Dives",
+	}
+	if got := Warnings(stats); !reflect.DeepEqual(got, want) {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+	if got := Warnings(nil); got != nil {
+		t.Errorf("warnings without phases = %q", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		40 * time.Second:  "40s",
+		70 * time.Second:  "1 minute",
+		12 * time.Minute:  "12 minutes",
+		150 * time.Second: "3 minutes",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
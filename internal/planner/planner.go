@@ -0,0 +1,187 @@
+// Package planner lays out a day-by-day study plan up to an interview
+// date: weak skills first, then never-practiced ones, then reviews falling
+// due before the date, then everything else once, with the time left
+// spent revisiting the weakest skills.
+package planner
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// WeakRating is the average rating below which a skill counts as weak
+	// and gets a second session.
+	WeakRating = 2.5
+	// MinGap is the fewest days between two sessions on the same skill.
+	MinGap = 3
+)
+
+// SessionMinutes is roughly how long a session takes in a domain.
+func SessionMinutes(domain string) int {
+	switch domain {
+	case "system-design-practical":
+		return 45
+	case "estimation":
+		return 10
+	}
+	return 15
+}
+
+// Skill is a skill the plan may schedule, with the user's history on it.
+type Skill struct {
+	ID        string
+	Domain    string
+	Sessions  int       // finished sessions; 0 means never practiced
+	AvgRating float64   // over those sessions
+	Due       time.Time // next review; zero if never scheduled
+}
+
+func (s Skill) weak() bool {
+	return s.Sessions > 0 && s.AvgRating < WeakRating
+}
+
+// Day is one day of the plan.
+type Day struct {
+	Date   string // YYYY-MM-DD
+	Skills []string
+}
+
+// Plan is the schedule from the first day to the interview date.
+type Plan struct {
+	Days        []Day
+	Unscheduled []string // skills that didn't fit before the date
+}
+
+// Build plans the days from through until (YYYY-MM-DD, inclusive) with
+// about minutesPerDay of drilling a day. A day always has room for one
+// session, even one longer than the budget.
+func Build(candidates []Skill, from, until string, minutesPerDay int) Plan {
+	start, err1 := time.Parse(time.DateOnly, from)
+	end, err2 := time.Parse(time.DateOnly, until)
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return Plan{}
+	}
+	days := int(end.Sub(start).Hours()/24) + 1
+	p := &planning{
+		budget: minutesPerDay,
+		byDay:  make([][]string, days),
+		used:   make([]int, days),
+		on:     map[string][]int{},
+	}
+
+	var weak, fresh, due, rest []Skill
+	for _, s := range candidates {
+		switch {
+		case s.weak():
+			weak = append(weak, s)
+		case s.Sessions == 0:
+			fresh = append(fresh, s)
+		case !s.Due.IsZero() && !s.Due.After(end.AddDate(0, 0, 1)):
+			due = append(due, s)
+		default:
+			rest = append(rest, s)
+		}
+	}
+	byRating := func(list []Skill) {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].AvgRating < list[j].AvgRating })
+	}
+	byRating(weak)
+	byRating(rest)
+	sort.SliceStable(due, func(i, j int) bool { return due[i].Due.Before(due[j].Due) })
+
+	var result Plan
+	// Everything once.
+	for _, group := range [][]Skill{weak, fresh, due, rest} {
+		for _, s := range group {
+			if !p.place(s, 0) {
+				result.Unscheduled = append(result.Unscheduled, s.ID)
+			}
+		}
+	}
+	// A second session for what is weak or new.
+	for _, group := range [][]Skill{weak, fresh} {
+		for _, s := range group {
+			if days := p.on[s.ID]; len(days) > 0 {
+				p.place(s, days[0]+MinGap)
+			}
+		}
+	}
+	// Spare time goes to the weakest skills, in rotation.
+	rotation := append(append(append(append([]Skill{}, weak...), fresh...), due...), rest...)
+	p.fill(rotation)
+
+	for i, skills := range p.byDay {
+		result.Days = append(result.Days, Day{
+			Date:   start.AddDate(0, 0, i).Format(time.DateOnly),
+			Skills: skills,
+		})
+	}
+	return result
+}
+
+type planning struct {
+	budget int
+	byDay  [][]string
+	used   []int            // minutes planned per day
+	on     map[string][]int // days each skill is planned on
+}
+
+// fits reports whether s can go on day d: the day has room, and s isn't
+// planned within MinGap days of it.
+func (p *planning) fits(s Skill, d int) bool {
+	cost := SessionMinutes(s.Domain)
+	if p.used[d] > 0 && p.used[d]+cost > p.budget {
+		return false
+	}
+	for _, other := range p.on[s.ID] {
+		if abs(other-d) < MinGap {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *planning) add(s Skill, d int) {
+	p.byDay[d] = append(p.byDay[d], s.ID)
+	p.used[d] += SessionMinutes(s.Domain)
+	p.on[s.ID] = append(p.on[s.ID], d)
+}
+
+// place puts s on the first day from earliest that fits.
+func (p *planning) place(s Skill, earliest int) bool {
+	for d := earliest; d < len(p.byDay); d++ {
+		if p.fits(s, d) {
+			p.add(s, d)
+			return true
+		}
+	}
+	return false
+}
+
+// fill tops up each day from the rotation until nothing more fits.
+func (p *planning) fill(rotation []Skill) {
+	if len(rotation) == 0 {
+		return
+	}
+	next := 0
+	for d := range p.byDay {
+		for tried := 0; tried < len(rotation); {
+			s := rotation[next%len(rotation)]
+			next++
+			if p.fits(s, d) {
+				p.add(s, d)
+				tried = 0
+				continue
+			}
+			tried++
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,81 @@
+package planner
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func catalog(n int) []Skill {
+	var out []Skill
+	for i := range n {
+		out = append(out, Skill{ID: fmt.Sprintf("s%02d", i), Domain: "system-design", Sessions: 2, AvgRating: 3})
+	}
+	return out
+}
+
+func TestBuildCoversEverythingWeakFirst(t *testing.T) {
+	skills := catalog(10)
+	skills[7].AvgRating = 1.5 // weak
+	skills[8].Sessions = 0    // never practiced
+	skills[9].Domain = "system-design-practical"
+
+	p := Build(skills, "2026-10-19", "2026-11-07", 30)
+	if len(p.Days) != 20 || p.Days[0].Date != "2026-10-19" || p.Days[19].Date != "2026-11-07" {
+		t.Fatalf("days = %d, %s..%s", len(p.Days), p.Days[0].Date, p.Days[len(p.Days)-1].Date)
+	}
+	if len(p.Unscheduled) != 0 {
+		t.Errorf("unscheduled = %v", p.Unscheduled)
+	}
+	if got := p.Days[0].Skills; len(got) != 2 || got[0] != "s07" || got[1] != "s08" {
+		t.Errorf("day 1 = %v, want the weak skill then the new one", got)
+	}
+
+	planned := map[string][]int{}
+	for i, d := range p.Days {
+		minutes := 0
+		for _, id := range d.Skills {
+			planned[id] = append(planned[id], i)
+			for _, s := range skills {
+				if s.ID == id {
+					minutes += SessionMinutes(s.Domain)
+				}
+			}
+		}
+		if minutes > 30 && len(d.Skills) > 1 {
+			t.Errorf("%s is over budget: %v (%d min)", d.Date, d.Skills, minutes)
+		}
+	}
+	for _, s := range skills {
+		days := planned[s.ID]
+		if len(days) == 0 {
+			t.Errorf("%s never planned", s.ID)
+		}
+		for i := 1; i < len(days); i++ {
+			if days[i]-days[i-1] < MinGap {
+				t.Errorf("%s planned on days %v, closer than %d apart", s.ID, days, MinGap)
+			}
+		}
+	}
+	if len(planned["s07"]) < 2 || len(planned["s08"]) < 2 {
+		t.Errorf("weak and new skills should get a second session: %v, %v", planned["s07"], planned["s08"])
+	}
+}
+
+func TestBuildDueReviewsBeforeTheRest(t *testing.T) {
+	skills := catalog(3)
+	skills[2].Due = time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	p := Build(skills, "2026-10-19", "2026-10-19", 15)
+	if got := p.Days[0].Skills; len(got) != 1 || got[0] != "s02" {
+		t.Errorf("day 1 = %v, want the due review", got)
+	}
+	if len(p.Unscheduled) != 2 {
+		t.Errorf("unscheduled = %v, want the two that didn't fit", p.Unscheduled)
+	}
+}
+
+func TestBuildBadDates(t *testing.T) {
+	if p := Build(catalog(2), "2026-11-08", "2026-11-07", 30); len(p.Days) != 0 {
+		t.Errorf("plan ending before it starts = %+v", p)
+	}
+}
@@ -0,0 +1,134 @@
+// Package questions summarizes the coach's questions and spots repeats, so
+// past sessions can steer the coach toward new ground.
+package questions
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Threshold is the Jaccard similarity of word shingles above which two
+// questions count as the same.
+const Threshold = 0.6
+
+// maxSummary caps the length of a summary, in bytes.
+const maxSummary = 240
+
+// Summary returns the question a coach message asks: the paragraph with
+// its last question mark, plus the paragraph before it when the question
+// alone is too short to say what was asked ("How would you approach it?").
+// Messages without a question mark summarize to their last paragraph.
+func Summary(text string) string {
+	var paras []string
+	for _, p := range strings.Split(text, "\n\n") {
+		if p = plain(p); p != "" {
+			paras = append(paras, p)
+		}
+	}
+	if len(paras) == 0 {
+		return ""
+	}
+	i := len(paras) - 1
+	for j := len(paras) - 1; j >= 0; j-- {
+		if strings.Contains(paras[j], "?") {
+			i = j
+			break
+		}
+	}
+	s := paras[i]
+	if i > 0 && len(strings.Fields(s)) < 12 {
+		s = paras[i-1] + " " + s
+	}
+	return truncate(s, maxSummary)
+}
+
+// plain strips markdown emphasis, headings and list markers and collapses
+// whitespace.
+func plain(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimLeft(line, "#>-* ")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	s = strings.Join(lines, " ")
+	s = strings.NewReplacer("**", "", "__", "", "`", "").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := strings.LastIndex(s[:n], " ")
+	if cut <= 0 {
+		cut = n
+	}
+	return strings.TrimRight(s[:cut], ",;:") + "…"
+}
+
+// words lowercases s and splits it into words, dropping punctuation.
+func words(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Shingles returns the set of adjacent word pairs in s. Text of a single
+// word is its own shingle.
+func Shingles(s string) map[string]bool {
+	w := words(s)
+	set := make(map[string]bool)
+	if len(w) == 1 {
+		set[w[0]] = true
+	}
+	for i := 0; i+1 < len(w); i++ {
+		set[w[i]+" "+w[i+1]] = true
+	}
+	return set
+}
+
+// Jaccard is the size of the intersection of a and b over their union, 0
+// when both are empty.
+func Jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for s := range a {
+		if b[s] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// Similar reports whether two questions are near-identical.
+func Similar(a, b string) bool {
+	return Jaccard(Shingles(a), Shingles(b)) >= Threshold
+}
+
+// Unused returns the example problems that none of the used problems name.
+// A used problem names an example if either contains the other once case
+// and punctuation are ignored ("two sum" names "Two Sum", and so does
+// "Two Sum II").
+func Unused(examples, used []string) []string {
+	var out []string
+	for _, ex := range examples {
+		e := strings.Join(words(ex), " ")
+		taken := false
+		for _, u := range used {
+			u := strings.Join(words(u), " ")
+			if u != "" && (strings.Contains(u, e) || strings.Contains(e, u)) {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			out = append(out, ex)
+		}
+	}
+	return out
+}
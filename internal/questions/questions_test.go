@@ -0,0 +1,84 @@
+package questions
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{
+			"question paragraph",
+			"Good - you got the O(1) lookup.\n\n**Next:** how does a hash map handle *collisions* when two keys hash to the same bucket?",
+			"Next: how does a hash map handle *collisions* when two keys hash to the same bucket?",
+		},
+		{
+			"short question keeps its setup",
+			"Given an array of integers and a target, return the indices of the two numbers that add up to the target.\n\nHow would you approach it?",
+			"Given an array of integers and a target, return the indices of the two numbers that add up to the target. How would you approach it?",
+		},
+		{
+			"no question mark",
+			"Right.\n\n- Walk me through what happens when the table resizes and every key has to be rehashed.",
+			"Walk me through what happens when the table resizes and every key has to be rehashed.",
+		},
+		{"empty", " \n\n ", ""},
+	}
+	for _, tt := range tests {
+		if got := Summary(tt.text); got != tt.want {
+			t.Errorf("%s: Summary = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSummaryTruncates(t *testing.T) {
+	long := ""
+	for i := 0; i < 60; i++ {
+		long += "word "
+	}
+	got := Summary(long + "?")
+	if len(got) > maxSummary+len("…") || got[len(got)-len("…"):] != "…" {
+		t.Errorf("Summary of a long question = %q", got)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	same := [][2]string{
+		{"Explain how a hash map handles collisions.", "Can you explain how a hash map handles collisions?"},
+		{"Walk me through Two Sum.", "walk me through two sum"},
+	}
+	for _, p := range same {
+		if !Similar(p[0], p[1]) {
+			t.Errorf("Similar(%q, %q) = false", p[0], p[1])
+		}
+	}
+	different := [][2]string{
+		{"Explain how a hash map handles collisions.", "What is the time complexity of a hash map lookup?"},
+		{"Walk me through Two Sum.", "Walk me through Group Anagrams."},
+		{"", ""},
+	}
+	for _, p := range different {
+		if Similar(p[0], p[1]) {
+			t.Errorf("Similar(%q, %q) = true", p[0], p[1])
+		}
+	}
+}
+
+func TestJaccard(t *testing.T) {
+	a := map[string]bool{"a b": true, "b c": true, "c d": true}
+	b := map[string]bool{"b c": true, "c d": true, "d e": true}
+	if got := Jaccard(a, b); got != 0.5 {
+		t.Errorf("Jaccard = %v, want 0.5", got)
+	}
+}
+
+func TestUnused(t *testing.T) {
+	examples := []string{"Two Sum", "Group Anagrams", "LRU Cache", "Word Search II"}
+	used := []string{"two-sum", "Word Search", ""}
+	want := []string{"Group Anagrams", "LRU Cache"}
+	if got := Unused(examples, used); !reflect.DeepEqual(got, want) {
+		t.Errorf("Unused = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+// Package recall builds self-graded flash cards from material bonk already
+// has locally, for drilling without the API.
+package recall
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"bonk/internal/skills"
+)
+
+// Card sources
+const (
+	SourceFacet      = "facet"      // a facet of the skill
+	SourceDeepDive   = "deep dive"  // a question from the skill's guide
+	SourceCorrection = "correction" // what the coach said to improve last time
+)
+
+// Card is a prompt to answer in your head and the material to check
+// yourself against.
+type Card struct {
+	Source string
+	Facet  string // set for facet cards
+	Front  string
+	Back   string
+}
+
+// Correction is a past coach assessment of the skill.
+type Correction struct {
+	Date       string // YYYY-MM-DD
+	Assessment string
+}
+
+// Cards returns every card for a skill: one per facet, one per deep dive
+// in its guide and one per past correction.
+func Cards(skill *skills.Skill, guide string, corrections []Correction) []Card {
+	var cards []Card
+	for _, facet := range skill.Facets {
+		cards = append(cards, Card{
+			Source: SourceFacet,
+			Facet:  facet,
+			Front:  fmt.Sprintf("%s: explain %s. Say it out loud or jot it down before revealing.", skill.Name, facet),
+			Back:   facetBack(skill),
+		})
+	}
+	for _, dd := range DeepDives(guide) {
+		cards = append(cards, Card{Source: SourceDeepDive, Front: dd.Front, Back: dd.Back})
+	}
+	for _, c := range corrections {
+		back := ToImprove(c.Assessment)
+		if back == "" {
+			continue
+		}
+		cards = append(cards, Card{
+			Source: SourceCorrection,
+			Front:  fmt.Sprintf("%s, session of %s: what did the coach tell you to work on, and what is the right answer?", skill.Name, c.Date),
+			Back:   back,
+		})
+	}
+	return cards
+}
+
+// Deck picks up to n cards at random, keeping at least one of each source
+// when there are enough.
+func Deck(cards []Card, n int, rng *rand.Rand) []Card {
+	shuffled := append([]Card(nil), cards...)
+	rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	if len(shuffled) <= n {
+		return shuffled
+	}
+
+	var deck, rest []Card
+	seen := make(map[string]bool)
+	for _, c := range shuffled {
+		if !seen[c.Source] {
+			seen[c.Source] = true
+			deck = append(deck, c)
+		} else {
+			rest = append(rest, c)
+		}
+	}
+	deck = append(deck, rest...)[:n]
+	rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	return deck
+}
+
+func facetBack(skill *skills.Skill) string {
+	var b strings.Builder
+	b.WriteString(skill.Description)
+	if len(skill.ExampleProblems) > 0 {
+		b.WriteString("\n\nCould you apply it to:\n- ")
+		b.WriteString(strings.Join(skill.ExampleProblems, "\n- "))
+	}
+	return b.String()
+}
+
+// DeepDives reads the questions in a guide's "Deep Dives" (or "Common Deep
+// Dives") section. Each starts with a bold line; the lines up to the next
+// one are its answer.
+func DeepDives(guide string) []Card {
+	var cards []Card
+	inSection := false
+	for _, line := range strings.Split(guide, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "## ") {
+			inSection = strings.HasSuffix(trimmed, "Deep Dives")
+			continue
+		}
+		if !inSection || trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "**") {
+			cards = append(cards, Card{Source: SourceDeepDive, Front: strings.ReplaceAll(trimmed, "**", "")})
+			continue
+		}
+		if n := len(cards); n > 0 {
+			if cards[n-1].Back != "" {
+				cards[n-1].Back += "\n"
+			}
+			cards[n-1].Back += trimmed
+		}
+	}
+	return cards
+}
+
+// ToImprove returns the "To improve" part of a coach assessment, or ""
+// if it has none.
+func ToImprove(assessment string) string {
+	i := strings.Index(assessment, "To improve")
+	if i < 0 {
+		return ""
+	}
+	text := assessment[i+len("To improve"):]
+	text = strings.TrimLeft(text, ":* ")
+	// Stop at the next feedback heading, if the coach wrote more after it.
+	for _, heading := range []string{"✓", "**Delivery", "**Content"} {
+		if j := strings.Index(text, heading); j > 0 {
+			text = text[:j]
+		}
+	}
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(text), "*"))
+}
+
+// Rating turns self grades into a session rating: their average, rounded
+// half up. It is 0 without grades.
+func Rating(grades []int) int {
+	if len(grades) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, g := range grades {
+		sum += g
+	}
+	return (2*sum + len(grades)) / (2 * len(grades))
+}
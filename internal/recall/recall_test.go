@@ -0,0 +1,88 @@
+package recall
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"bonk/internal/skills"
+)
+
+func TestDeepDives(t *testing.T) {
+	guide := `# Design X
+
+## Functional Requirements
+- **not a question**
+
+## Common Deep Dives
+
+**How do you handle hot keys?**
+- Replicate hot keys
+- Add a local cache
+
+**Fan-out**: push or pull?
+- Hybrid for celebrities
+
+## Red Flags to Avoid
+- Ignoring hot keys`
+
+	want := []Card{
+		{Source: SourceDeepDive, Front: "How do you handle hot keys?", Back: "- Replicate hot keys\n- Add a local cache"},
+		{Source: SourceDeepDive, Front: "Fan-out: push or pull?", Back: "- Hybrid for celebrities"},
+	}
+	if got := DeepDives(guide); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeepDives = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestEveryGuideHasDeepDives(t *testing.T) {
+	for _, s := range skills.List() {
+		if guide := skills.GetGuide(s.ID); guide != "" && len(DeepDives(guide)) == 0 {
+			t.Errorf("%s: no deep dives found in guide", s.ID)
+		}
+	}
+}
+
+func TestToImprove(t *testing.T) {
+	assessment := "You got the O(n) idea.\n\n✓ Strengths: clear approach\n✗ To improve: you never mentioned resizing; the load factor triggers a rehash.\n"
+	if got := ToImprove(assessment); got != "you never mentioned resizing; the load factor triggers a rehash." {
+		t.Errorf("ToImprove = %q", got)
+	}
+	if got := ToImprove("**✗ To improve:** hedging\n\n**✓ Strengths:** pace"); got != "hedging" {
+		t.Errorf("ToImprove with a later heading = %q", got)
+	}
+	if got := ToImprove("Great session."); got != "" {
+		t.Errorf("ToImprove without the section = %q", got)
+	}
+}
+
+func TestDeckKeepsEachSource(t *testing.T) {
+	skill := skills.Get("design-twitter")
+	cards := Cards(skill, skills.GetGuide(skill.ID), []Correction{
+		{Date: "2026-01-02", Assessment: "✗ To improve: fan-out for celebrities"},
+		{Date: "2026-01-01", Assessment: "no improvement section"},
+	})
+	deck := Deck(cards, 3, rand.New(rand.NewSource(1)))
+	if len(deck) != 3 {
+		t.Fatalf("deck has %d cards", len(deck))
+	}
+	sources := make(map[string]bool)
+	for _, c := range deck {
+		sources[c.Source] = true
+	}
+	if len(sources) != 3 {
+		t.Errorf("deck sources = %v", sources)
+	}
+}
+
+func TestRating(t *testing.T) {
+	for grades, want := range map[string]int{"": 0, "4": 4, "34": 4, "1223": 2, "12": 2, "112": 1} {
+		var gs []int
+		for _, c := range grades {
+			gs = append(gs, int(c-'0'))
+		}
+		if got := Rating(gs); got != want {
+			t.Errorf("Rating(%v) = %d, want %d", gs, got, want)
+		}
+	}
+}
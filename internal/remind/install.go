@@ -0,0 +1,184 @@
+package remind
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Methods for scheduling the reminder.
+const (
+	MethodSystemd = "systemd"
+	MethodCron    = "cron"
+)
+
+// DefaultMethod picks a systemd user timer where a user systemd instance
+// is running, and cron everywhere else.
+func DefaultMethod() string {
+	if runtime.GOOS != "linux" || !hasBinary("systemctl") {
+		return MethodCron
+	}
+	// is-system-running exits non-zero when degraded, which still runs
+	// timers, so go by what it prints.
+	out, _ := exec.Command("systemctl", "--user", "is-system-running").Output()
+	switch strings.TrimSpace(string(out)) {
+	case "running", "degraded", "starting":
+		return MethodSystemd
+	}
+	return MethodCron
+}
+
+// Install schedules the job with method, replacing any earlier reminder
+// however it was scheduled. It returns where the job was written.
+func Install(method string, j Job) (string, error) {
+	if method != MethodSystemd && method != MethodCron {
+		return "", fmt.Errorf("unknown method %q (want %s or %s)", method, MethodSystemd, MethodCron)
+	}
+	if _, err := Uninstall(); err != nil {
+		return "", err
+	}
+	if method == MethodCron {
+		return "your crontab", installCron(j)
+	}
+	return installSystemd(j)
+}
+
+// Uninstall removes the reminder however it was installed, and returns
+// what it removed; empty when there was nothing to remove.
+func Uninstall() ([]string, error) {
+	var removed []string
+	dir, err := unitDir()
+	if err != nil {
+		return nil, err
+	}
+	timer := filepath.Join(dir, Unit+".timer")
+	if _, err := os.Stat(timer); err == nil {
+		if hasBinary("systemctl") {
+			// Best effort: the timer may never have been enabled.
+			exec.Command("systemctl", "--user", "disable", "--now", Unit+".timer").Run()
+		}
+		for _, f := range []string{timer, filepath.Join(dir, Unit+".service")} {
+			if err := os.Remove(f); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return removed, fmt.Errorf("remove %s: %w", f, err)
+			}
+		}
+		if hasBinary("systemctl") {
+			exec.Command("systemctl", "--user", "daemon-reload").Run()
+		}
+		removed = append(removed, "systemd timer "+Unit+".timer")
+	}
+
+	if hasBinary("crontab") {
+		crontab, err := readCrontab()
+		if err != nil {
+			return removed, err
+		}
+		if HasCron(crontab) {
+			if err := writeCrontab(WithoutCron(crontab)); err != nil {
+				return removed, err
+			}
+			removed = append(removed, "crontab entry")
+		}
+	}
+	return removed, nil
+}
+
+// Notify shows a desktop notification with notify-send, or osascript on
+// macOS.
+func Notify(title, body string) error {
+	var cmd *exec.Cmd
+	switch {
+	case hasBinary("notify-send"):
+		cmd = exec.Command("notify-send", "--app-name=bonk", title, body)
+	case runtime.GOOS == "darwin":
+		cmd = exec.Command("osascript", "-e",
+			fmt.Sprintf("display notification %q with title %q", body, title))
+	default:
+		return fmt.Errorf("notify-send not found (install libnotify)")
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("notify: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func unitDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("find config directory: %w", err)
+	}
+	return filepath.Join(dir, "systemd", "user"), nil
+}
+
+func installSystemd(j Job) (string, error) {
+	dir, err := unitDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create %s: %w", dir, err)
+	}
+	units := map[string]string{
+		Unit + ".service": j.Service(),
+		Unit + ".timer":   j.Timer(),
+	}
+	for name, content := range units {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			return "", fmt.Errorf("write %s: %w", name, err)
+		}
+	}
+	for _, args := range [][]string{
+		{"--user", "daemon-reload"},
+		{"--user", "enable", "--now", Unit + ".timer"},
+	} {
+		if out, err := exec.Command("systemctl", args...).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("systemctl %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+		}
+	}
+	return filepath.Join(dir, Unit+".timer"), nil
+}
+
+func installCron(j Job) error {
+	if !hasBinary("crontab") {
+		return fmt.Errorf("crontab not found")
+	}
+	crontab, err := readCrontab()
+	if err != nil {
+		return err
+	}
+	return writeCrontab(WithCron(crontab, j))
+}
+
+// readCrontab returns the user's crontab, or "" when they have none.
+func readCrontab() (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("crontab", "-l")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "no crontab") {
+			return "", nil
+		}
+		return "", fmt.Errorf("crontab -l: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+func writeCrontab(crontab string) error {
+	cmd := exec.Command("crontab", "-")
+	cmd.Stdin = strings.NewReader(crontab)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("crontab: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func hasBinary(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
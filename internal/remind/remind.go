@@ -0,0 +1,162 @@
+// Package remind schedules a daily `bonk due --notify` with a systemd user
+// timer or a crontab entry, and decides what that reminder should say.
+package remind
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bonk/internal/streak"
+)
+
+// Unit is the name shared by the systemd service and timer.
+const Unit = "bonk-remind"
+
+// cronMarker tags the crontab line bonk owns so it can be replaced or
+// removed without touching anything else.
+const cronMarker = "# bonk remind"
+
+// Job is what the reminder runs and when.
+type Job struct {
+	At      time.Duration // time of day, from midnight
+	Command []string      // bonk's absolute path and arguments
+	Env     []string      // KEY=value pairs the command needs
+}
+
+// ParseAt parses a 24-hour HH:MM time of day.
+func ParseAt(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (want HH:MM, e.g. 09:00)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (j Job) clock() (hour, minute int) {
+	return int(j.At / time.Hour), int(j.At % time.Hour / time.Minute)
+}
+
+// Service returns the systemd user service that runs the job once.
+func (j Job) Service() string {
+	var b strings.Builder
+	b.WriteString("[Unit]\nDescription=bonk review reminder\n\n[Service]\nType=oneshot\n")
+	for _, e := range j.Env {
+		fmt.Fprintf(&b, "Environment=%s\n", quoteSystemd(e))
+	}
+	quoted := make([]string, len(j.Command))
+	for i, arg := range j.Command {
+		quoted[i] = quoteSystemd(arg)
+	}
+	fmt.Fprintf(&b, "ExecStart=%s\n", strings.Join(quoted, " "))
+	return b.String()
+}
+
+// Timer returns the systemd user timer that starts the service daily.
+// Persistent catches up on a reminder missed while the machine was off.
+func (j Job) Timer() string {
+	hour, minute := j.clock()
+	return fmt.Sprintf(`[Unit]
+Description=Daily bonk review reminder
+
+[Timer]
+OnCalendar=*-*-* %02d:%02d:00
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, hour, minute)
+}
+
+// CronLine returns the crontab entry for the job.
+func (j Job) CronLine() string {
+	hour, minute := j.clock()
+	parts := []string{fmt.Sprintf("%d %d * * *", minute, hour)}
+	for _, e := range j.Env {
+		parts = append(parts, quoteShell(e))
+	}
+	for _, arg := range j.Command {
+		parts = append(parts, quoteShell(arg))
+	}
+	return strings.Join(parts, " ") + " " + cronMarker
+}
+
+// WithCron returns crontab with the job's line in place of any earlier one.
+func WithCron(crontab string, j Job) string {
+	return WithoutCron(crontab) + j.CronLine() + "\n"
+}
+
+// WithoutCron returns crontab without bonk's line. Other lines, blank ones
+// included, are kept as they are.
+func WithoutCron(crontab string) string {
+	if crontab == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(crontab, "\n"), "\n") {
+		if strings.HasSuffix(line, cronMarker) {
+			continue
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// HasCron reports whether crontab has bonk's line.
+func HasCron(crontab string) bool {
+	for _, line := range strings.Split(crontab, "\n") {
+		if strings.HasSuffix(line, cronMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Message returns the reminder for the given state, or ok=false when there
+// is nothing to remind about: no reviews due and the streak is safe.
+func Message(due int, s streak.Status) (msg string, ok bool) {
+	keep := fmt.Sprintf("Drill today to keep your %d-day streak.", s.Current)
+	if s.Freezes > 0 {
+		keep = fmt.Sprintf("Drill today to keep your %d-day streak without spending a freeze.", s.Current)
+	}
+	switch {
+	case due > 0 && s.AtRisk():
+		return fmt.Sprintf("%s due. %s", reviews(due), keep), true
+	case due > 0:
+		return fmt.Sprintf("%s due.", reviews(due)), true
+	case s.AtRisk():
+		return keep, true
+	}
+	return "", false
+}
+
+func reviews(n int) string {
+	if n == 1 {
+		return "1 review"
+	}
+	return fmt.Sprintf("%d reviews", n)
+}
+
+// quoteSystemd quotes an ExecStart argument or Environment assignment
+// when it contains spaces or quotes, and doubles % so systemd doesn't read
+// it as a specifier.
+func quoteSystemd(s string) string {
+	s = strings.ReplaceAll(s, "%", "%%")
+	if !strings.ContainsAny(s, " \t\"'\\") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// quoteShell quotes a word for the shell cron runs lines with. An
+// assignment keeps its name unquoted so the shell still treats it as one.
+func quoteShell(s string) string {
+	if !strings.ContainsAny(s, " \t\"'\\$`;&|<>()*?%#~") {
+		return s
+	}
+	if name, value, ok := strings.Cut(s, "="); ok && name != "" && !strings.ContainsAny(name, " '\"") {
+		return name + "=" + quoteShell(value)
+	}
+	// % is special in crontab lines even inside quotes.
+	return "'" + strings.NewReplacer("'", `'\''`, "%", `\%`).Replace(s) + "'"
+}
@@ -0,0 +1,95 @@
+package remind
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"bonk/internal/streak"
+)
+
+func TestParseAt(t *testing.T) {
+	at, err := ParseAt("09:05")
+	if err != nil || at != 9*time.Hour+5*time.Minute {
+		t.Errorf("ParseAt(09:05) = %v, %v", at, err)
+	}
+	for _, s := range []string{"9am", "24:00", "12:60", ""} {
+		if _, err := ParseAt(s); err == nil {
+			t.Errorf("ParseAt(%q) succeeded", s)
+		}
+	}
+}
+
+func testJob() Job {
+	return Job{
+		At:      18*time.Hour + 30*time.Minute,
+		Command: []string{"/home/me/go bin/bonk", "due", "--notify"},
+		Env:     []string{"BONK_HOME=/home/me/.bonk"},
+	}
+}
+
+func TestSystemdUnits(t *testing.T) {
+	j := testJob()
+	service := j.Service()
+	for _, want := range []string{
+		"Type=oneshot\n",
+		"Environment=BONK_HOME=/home/me/.bonk\n",
+		`ExecStart="/home/me/go bin/bonk" due --notify` + "\n",
+	} {
+		if !strings.Contains(service, want) {
+			t.Errorf("service missing %q:\n%s", want, service)
+		}
+	}
+	j.Command[0] = "/home/me/100%/bonk"
+	if service := j.Service(); !strings.Contains(service, "ExecStart=/home/me/100%%/bonk due") {
+		t.Errorf("%% not escaped:\n%s", service)
+	}
+	if timer := j.Timer(); !strings.Contains(timer, "OnCalendar=*-*-* 18:30:00\n") || !strings.Contains(timer, "Persistent=true") {
+		t.Errorf("timer =\n%s", timer)
+	}
+}
+
+func TestCrontabEditing(t *testing.T) {
+	j := testJob()
+	line := j.CronLine()
+	if want := `30 18 * * * BONK_HOME=/home/me/.bonk '/home/me/go bin/bonk' due --notify # bonk remind`; line != want {
+		t.Errorf("cron line = %q, want %q", line, want)
+	}
+
+	existing := "MAILTO=me\n\n# nightly\n0 3 * * * backup.sh\n\n"
+	installed := WithCron(existing, j)
+	if installed != existing+line+"\n" || !HasCron(installed) {
+		t.Errorf("installed =\n%s", installed)
+	}
+	// Installing again replaces the line rather than adding another.
+	j.At = 7 * time.Hour
+	again := WithCron(installed, j)
+	if strings.Count(again, cronMarker) != 1 || !strings.Contains(again, "0 7 * * *") {
+		t.Errorf("reinstalled =\n%s", again)
+	}
+	if removed := WithoutCron(again); removed != existing || HasCron(removed) {
+		t.Errorf("removed = %q, want %q", removed, existing)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		due  int
+		s    streak.Status
+		want string
+	}{
+		{3, streak.Status{Current: 5}, "3 reviews due. Drill today to keep your 5-day streak."},
+		{1, streak.Status{Current: 5, DrilledToday: true}, "1 review due."},
+		{0, streak.Status{Current: 5}, "Drill today to keep your 5-day streak."},
+		{0, streak.Status{Current: 9, Freezes: 1}, "Drill today to keep your 9-day streak without spending a freeze."},
+		{2, streak.Status{}, "2 reviews due."},
+		{0, streak.Status{Current: 5, DrilledToday: true}, ""},
+		{0, streak.Status{}, ""},
+	}
+	for _, tt := range tests {
+		got, ok := Message(tt.due, tt.s)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("Message(%d, %+v) = %q, %v; want %q", tt.due, tt.s, got, ok, tt.want)
+		}
+	}
+}
@@ -55,7 +55,24 @@ func ListIDsByDomain() map[string][]string {
 }
 
 func Domains() []string {
-	return []string{"data-structures", "algorithm-patterns", "system-design", "system-design-practical", "leetcode-patterns"}
+	return []string{"data-structures", "algorithm-patterns", "system-design", "system-design-practical", "leetcode-patterns", "estimation"}
+}
+
+var domainNames = map[string]string{
+	"data-structures":         "Data Structures",
+	"algorithm-patterns":      "Algorithm Patterns",
+	"system-design":           "System Design",
+	"system-design-practical": "System Design Practical",
+	"leetcode-patterns":       "LeetCode Patterns",
+	"estimation":              "Estimation",
+}
+
+// DomainName returns a domain's display name, or the ID if it has none.
+func DomainName(domain string) string {
+	if name, ok := domainNames[domain]; ok {
+		return name
+	}
+	return domain
 }
 
 // Domain short names
@@ -74,6 +91,9 @@ var DomainMap = map[string]string{
 	"lc":                      "leetcode-patterns",
 	"leetcode":                "leetcode-patterns",
 	"leetcode-patterns":       "leetcode-patterns",
+	"est":                     "estimation",
+	"estimate":                "estimation",
+	"estimation":              "estimation",
 }
 
 func init() {
@@ -1770,4 +1790,56 @@ func init() {
 			"Insert Interval",
 		},
 	})
+
+	// Estimation (back-of-the-envelope; scenarios come from internal/estimate)
+	register(&Skill{
+		ID:          "estimate-qps",
+		Name:        "Traffic Estimation",
+		Domain:      "estimation",
+		Description: "Back-of-the-envelope request rates from user counts and usage patterns",
+		Facets: []string{
+			"daily volume to per-second rate (~100k seconds in a day)",
+			"read/write ratio",
+			"peak vs average traffic",
+			"rounding to powers of ten and stating assumptions",
+		},
+		ExampleProblems: []string{
+			"Average write QPS for a social feed with 100M daily users",
+			"Peak read QPS for a URL shortener",
+		},
+	})
+
+	register(&Skill{
+		ID:          "estimate-storage",
+		Name:        "Storage Estimation",
+		Domain:      "estimation",
+		Description: "Back-of-the-envelope storage growth from write volume and object size",
+		Facets: []string{
+			"object size including metadata",
+			"daily growth",
+			"retention and replication multipliers",
+			"unit conversions (KB, MB, GB, TB, PB)",
+		},
+		ExampleProblems: []string{
+			"Storage for five years of photo uploads",
+			"Daily data written by a chat app",
+		},
+	})
+
+	register(&Skill{
+		ID:          "estimate-bandwidth",
+		Name:        "Bandwidth Estimation",
+		Domain:      "estimation",
+		Description: "Back-of-the-envelope ingress and egress bandwidth",
+		Facets: []string{
+			"ingress from write volume and object size",
+			"egress from read fan-out",
+			"peak vs average traffic",
+			"bytes vs bits per second",
+		},
+		ExampleProblems: []string{
+			"Peak upload bandwidth for a video platform",
+			"Egress bandwidth for serving photos",
+		},
+	})
 }
@@ -0,0 +1,127 @@
+// Package streak works out which practice day a session counts toward, in
+// the user's time zone and with a rollover hour, and replays those days
+// into a streak with earned freezes.
+package streak
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// FreezeEvery is how many drilled days in a streak earn a freeze.
+	FreezeEvery = 7
+	// MaxFreezes caps the freezes banked at once.
+	MaxFreezes = 2
+)
+
+// Clock maps instants to practice days.
+type Clock struct {
+	Loc          *time.Location // nil means time.Local
+	RolloverHour int            // hour a new day starts: with 4, a 1am drill counts toward the day before
+}
+
+func (c Clock) loc() *time.Location {
+	if c.Loc == nil {
+		return time.Local
+	}
+	return c.Loc
+}
+
+// StartOfDay returns when the practice day containing t began.
+func (c Clock) StartOfDay(t time.Time) time.Time {
+	t = t.In(c.loc())
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, c.RolloverHour, 0, 0, 0, t.Location())
+	if t.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// StartOfWeek returns when the practice week containing t began. Weeks
+// start on Monday.
+func (c Clock) StartOfWeek(t time.Time) time.Time {
+	start := c.StartOfDay(t)
+	return start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+}
+
+// Day returns the practice day t counts toward, as YYYY-MM-DD.
+func (c Clock) Day(t time.Time) string {
+	return c.StartOfDay(t).Format(time.DateOnly)
+}
+
+// EndOfDay returns when the practice day named by day (YYYY-MM-DD) ends,
+// which is when the next one starts.
+func (c Clock) EndOfDay(day string) (time.Time, error) {
+	d, err := time.ParseInLocation(time.DateOnly, day, c.loc())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(d.Year(), d.Month(), d.Day()+1, c.RolloverHour, 0, 0, 0, d.Location()), nil
+}
+
+// Status is the state of the user's streak on a given day.
+type Status struct {
+	Current      int  // drilled days in the running streak
+	Longest      int  // longest streak ever, in drilled days
+	Freezes      int  // freezes banked for days to come
+	Frozen       int  // missed days the running streak survived on freezes
+	DrilledToday bool // today already counts
+}
+
+// AtRisk reports whether the streak ends unless the user drills today, or
+// spends a freeze.
+func (s Status) AtRisk() bool {
+	return s.Current > 0 && !s.DrilledToday
+}
+
+// Compute replays practice days (YYYY-MM-DD, in any order, repeats
+// allowed) up to today. Every FreezeEvery drilled days in a streak bank a
+// freeze, up to MaxFreezes; a missed day spends one instead of ending the
+// streak. Today is never a missed day: it isn't over yet.
+func Compute(days []string, today string) Status {
+	end, err := time.Parse(time.DateOnly, today)
+	if err != nil {
+		return Status{}
+	}
+	drilled := make(map[string]bool, len(days))
+	for _, d := range days {
+		if d <= today {
+			drilled[d] = true
+		}
+	}
+	if len(drilled) == 0 {
+		return Status{}
+	}
+	sorted := make([]string, 0, len(drilled))
+	for d := range drilled {
+		sorted = append(sorted, d)
+	}
+	sort.Strings(sorted)
+	start, err := time.Parse(time.DateOnly, sorted[0])
+	if err != nil {
+		return Status{}
+	}
+
+	var s Status
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		d := day.Format(time.DateOnly)
+		switch {
+		case drilled[d]:
+			s.Current++
+			s.Longest = max(s.Longest, s.Current)
+			if s.Current%FreezeEvery == 0 && s.Freezes < MaxFreezes {
+				s.Freezes++
+			}
+		case d == today:
+		case s.Current > 0 && s.Freezes > 0:
+			s.Freezes--
+			s.Frozen++
+		default:
+			s.Current, s.Frozen = 0, 0
+		}
+	}
+	s.DrilledToday = drilled[today]
+	return s
+}
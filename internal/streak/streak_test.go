@@ -0,0 +1,90 @@
+package streak
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClockDay(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+	// 11pm in California is already the next day in UTC.
+	late := time.Date(2026, 3, 10, 6, 0, 0, 0, time.UTC)
+	if got := (Clock{Loc: la}).Day(late); got != "2026-03-09" {
+		t.Errorf("Day = %s, want 2026-03-09", got)
+	}
+	// With a 4am rollover, 1am still counts toward the day before.
+	night := time.Date(2026, 3, 10, 1, 0, 0, 0, la)
+	c := Clock{Loc: la, RolloverHour: 4}
+	if got := c.Day(night); got != "2026-03-09" {
+		t.Errorf("Day(1am) = %s, want 2026-03-09", got)
+	}
+	if got := c.Day(night.Add(3 * time.Hour)); got != "2026-03-10" {
+		t.Errorf("Day(4am) = %s, want 2026-03-10", got)
+	}
+	// 2026-03-12 is a Thursday; the week began Monday at the rollover hour.
+	week := c.StartOfWeek(time.Date(2026, 3, 12, 12, 0, 0, 0, la))
+	if want := time.Date(2026, 3, 9, 4, 0, 0, 0, la); !week.Equal(want) {
+		t.Errorf("StartOfWeek = %v, want %v", week, want)
+	}
+	// The 9th runs until 4am on the 10th, so a 1am drill is still in it.
+	end, err := c.EndOfDay("2026-03-09")
+	if want := time.Date(2026, 3, 10, 4, 0, 0, 0, la); err != nil || !end.Equal(want) {
+		t.Errorf("EndOfDay = %v, %v, want %v", end, err, want)
+	}
+	if !night.Before(end) {
+		t.Errorf("1am on the 10th is after the end of the 9th")
+	}
+}
+
+func TestCompute(t *testing.T) {
+	days := func(from string, n int) []string {
+		start, _ := time.Parse(time.DateOnly, from)
+		var out []string
+		for i := range n {
+			out = append(out, start.AddDate(0, 0, i).Format(time.DateOnly))
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		days  []string
+		today string
+		want  Status
+	}{
+		{"none", nil, "2026-01-10", Status{}},
+		{"today", []string{"2026-01-10", "2026-01-10"}, "2026-01-10", Status{Current: 1, Longest: 1, DrilledToday: true}},
+		{"yesterday still counts", days("2026-01-07", 3), "2026-01-10", Status{Current: 3, Longest: 3}},
+		{"missed a day", days("2026-01-06", 3), "2026-01-10", Status{Longest: 3}},
+		{"a week earns a freeze", days("2026-01-01", 7), "2026-01-08", Status{Current: 7, Longest: 7, Freezes: 1}},
+		{
+			"freeze bridges a missed day",
+			append(days("2026-01-01", 7), "2026-01-09"), "2026-01-09",
+			Status{Current: 8, Longest: 8, Frozen: 1, DrilledToday: true},
+		},
+		{
+			"two missed days need two freezes",
+			append(days("2026-01-01", 7), "2026-01-10"), "2026-01-10",
+			Status{Current: 1, Longest: 7, DrilledToday: true},
+		},
+		{"freezes are capped", days("2026-01-01", 30), "2026-01-30", Status{Current: 30, Longest: 30, Freezes: MaxFreezes, DrilledToday: true}},
+		{"future days are ignored", []string{"2026-01-11"}, "2026-01-10", Status{}},
+	}
+	for _, tt := range tests {
+		if got := Compute(tt.days, tt.today); got != tt.want {
+			t.Errorf("%s: Compute = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAtRisk(t *testing.T) {
+	if !(Status{Current: 3}).AtRisk() {
+		t.Error("undrilled streak not at risk")
+	}
+	if (Status{Current: 3, DrilledToday: true}).AtRisk() || (Status{}).AtRisk() {
+		t.Error("safe streak at risk")
+	}
+}
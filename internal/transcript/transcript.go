@@ -0,0 +1,336 @@
+// Package transcript renders a finished session as a shareable document:
+// markdown, self-contained HTML or JSON.
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+
+	"bonk/internal/db"
+	"bonk/internal/pacing"
+	"bonk/internal/skills"
+)
+
+// Formats are the document formats Render accepts.
+var Formats = []string{"md", "html", "json"}
+
+// Transcript is a session laid out for reading outside bonk.
+type Transcript struct {
+	SkillID       string `json:"skill_id"`
+	Skill         string `json:"skill"`
+	Domain        string `json:"domain,omitempty"`
+	StartedAt     string `json:"started_at"`
+	FinishedAt    string `json:"finished_at,omitempty"`
+	Rating        int    `json:"rating"`
+	SelfRating    int    `json:"self_rating,omitempty"`
+	CoachRating   int    `json:"coach_rating,omitempty"`
+	PromptVersion string `json:"prompt_version,omitempty"`
+	Turns         []Turn `json:"turns"`
+	Assessment    string `json:"assessment,omitempty"` // the coach's closing message
+	Feedback      string `json:"feedback,omitempty"`   // AI feedback, when asked for
+}
+
+// Turn is one coach message and the answer to it.
+type Turn struct {
+	Number      int       `json:"turn"`
+	Phase       string    `json:"phase,omitempty"`
+	Facet       string    `json:"facet,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	CoachRating int       `json:"coach_rating,omitempty"`
+	Question    string    `json:"question"`
+	Answer      string    `json:"answer"`
+	Check       string    `json:"check,omitempty"`
+	Hints       int       `json:"hints,omitempty"`
+	Revealed    bool      `json:"revealed,omitempty"`
+	Delivery    *Delivery `json:"delivery,omitempty"`
+}
+
+// Delivery is the voice delivery of a spoken answer.
+type Delivery struct {
+	SpeechSeconds  float64 `json:"speech_seconds"`
+	WordsPerMinute float64 `json:"words_per_minute"`
+	Fillers        int     `json:"fillers"`
+	Hedges         int     `json:"hedges"`
+	PauseRatio     float64 `json:"pause_ratio"`
+}
+
+// New lays out a session. The closing assessment becomes Assessment rather
+// than a turn.
+func New(s *db.SessionDetail) *Transcript {
+	t := &Transcript{
+		SkillID:       s.SkillID,
+		Skill:         s.SkillID,
+		StartedAt:     s.StartedAt,
+		FinishedAt:    s.FinishedAt,
+		Rating:        s.Rating,
+		SelfRating:    s.SelfRating,
+		CoachRating:   s.CoachRating,
+		PromptVersion: s.PromptVersion,
+		Assessment:    s.Assessment,
+		Turns:         []Turn{},
+	}
+	if skill := skills.Get(s.SkillID); skill != nil {
+		t.Skill, t.Domain = skill.Name, skill.Domain
+	}
+	for _, ex := range s.Exchanges {
+		if ex.Final {
+			t.Assessment = ex.Question
+			continue
+		}
+		turn := Turn{
+			Number:      len(t.Turns) + 1,
+			Phase:       ex.Phase,
+			Facet:       ex.Facet,
+			Type:        ex.QuestionType,
+			CoachRating: ex.CoachRating,
+			Question:    ex.Question,
+			Answer:      ex.Answer,
+			Check:       ex.Check,
+			Hints:       ex.Hints,
+			Revealed:    ex.Revealed,
+		}
+		if d := ex.Delivery; d != nil {
+			turn.Delivery = &Delivery{
+				SpeechSeconds:  d.SpeechSeconds,
+				WordsPerMinute: d.WordsPerMinute,
+				Fillers:        d.Fillers,
+				Hedges:         d.Hedges,
+				PauseRatio:     d.PauseRatio,
+			}
+		}
+		t.Turns = append(t.Turns, turn)
+	}
+	return t
+}
+
+// Render returns the transcript in one of Formats.
+func (t *Transcript) Render(format string) ([]byte, error) {
+	switch format {
+	case "md":
+		return []byte(t.Markdown()), nil
+	case "html":
+		return t.HTML()
+	case "json":
+		b, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode transcript: %w", err)
+		}
+		return append(b, '\n'), nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want %s)", format, strings.Join(Formats, ", "))
+}
+
+// Markdown renders the transcript as a markdown document. Coach messages
+// are markdown already and go in as written; answers are quoted.
+func (t *Transcript) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", t.Skill)
+
+	b.WriteString("| | |\n|---|---|\n")
+	skill := "`" + t.SkillID + "`"
+	if t.Domain != "" {
+		skill += ", " + t.Domain
+	}
+	fmt.Fprintf(&b, "| Skill | %s (%s) |\n", t.Skill, skill)
+	fmt.Fprintf(&b, "| Date | %s |\n", dateTime(t.StartedAt))
+	if d := t.duration(); d > 0 {
+		fmt.Fprintf(&b, "| Duration | %s |\n", pacing.FormatDuration(d))
+	}
+	rating := fmt.Sprintf("%d/4", t.Rating)
+	if t.SelfRating > 0 && t.CoachRating > 0 {
+		rating += fmt.Sprintf(" (you %d, coach %d)", t.SelfRating, t.CoachRating)
+	}
+	fmt.Fprintf(&b, "| Rating | %s |\n", rating)
+	if trajectory := t.trajectory(); len(trajectory) > 1 {
+		fmt.Fprintf(&b, "| Coach rating by turn | %s |\n", strings.Join(trajectory, " → "))
+	}
+	if t.PromptVersion != "" {
+		fmt.Fprintf(&b, "| Prompt | `%s` |\n", t.PromptVersion)
+	}
+
+	b.WriteString("\n## Transcript\n")
+	turnHeading := "###"
+	for _, turn := range t.Turns {
+		if turn.Phase != "" {
+			turnHeading = "####"
+			break
+		}
+	}
+	phase := ""
+	for _, turn := range t.Turns {
+		if turn.Phase != "" && turn.Phase != phase {
+			phase = turn.Phase
+			fmt.Fprintf(&b, "\n### %s\n", pacing.Title(phase))
+		}
+		heading := fmt.Sprintf("Turn %d", turn.Number)
+		for _, s := range []string{turn.Facet, turn.Type} {
+			if s != "" {
+				heading += " · " + s
+			}
+		}
+		if turn.CoachRating > 0 {
+			heading += fmt.Sprintf(" · coach %d/4", turn.CoachRating)
+		}
+		fmt.Fprintf(&b, "\n%s %s\n\n", turnHeading, heading)
+		fmt.Fprintf(&b, "**Coach**\n\n%s\n\n", strings.TrimSpace(turn.Question))
+		b.WriteString("**You**\n\n")
+		b.WriteString(quote(turn.Answer))
+		if notes := turn.notes(); len(notes) > 0 {
+			fmt.Fprintf(&b, "\n*%s*\n", strings.Join(notes, " · "))
+		}
+	}
+
+	if t.Assessment != "" {
+		fmt.Fprintf(&b, "\n## Assessment\n\n%s\n", strings.TrimSpace(t.Assessment))
+	}
+	if t.Feedback != "" {
+		fmt.Fprintf(&b, "\n## AI Feedback\n\n%s\n", strings.TrimSpace(t.Feedback))
+	}
+	return b.String()
+}
+
+// notes are the extras shown under an answer. A revealed answer already
+// says so in place of the answer.
+func (turn Turn) notes() []string {
+	var notes []string
+	if turn.Check != "" {
+		notes = append(notes, "check: "+turn.Check)
+	}
+	if turn.Hints > 0 {
+		notes = append(notes, fmt.Sprintf("hints: %d", turn.Hints))
+	}
+	if d := turn.Delivery; d != nil && d.SpeechSeconds > 0 {
+		notes = append(notes, fmt.Sprintf("%.0f wpm, %d fillers, %d hedges, %.0f%% pauses",
+			d.WordsPerMinute, d.Fillers, d.Hedges, d.PauseRatio*100))
+	} else if d != nil {
+		notes = append(notes, fmt.Sprintf("%d fillers, %d hedges", d.Fillers, d.Hedges))
+	}
+	return notes
+}
+
+func (t *Transcript) trajectory() []string {
+	var out []string
+	for _, turn := range t.Turns {
+		if turn.CoachRating > 0 {
+			out = append(out, strconv.Itoa(turn.CoachRating))
+		}
+	}
+	return out
+}
+
+func (t *Transcript) duration() time.Duration {
+	start, err1 := time.Parse(time.DateTime, t.StartedAt)
+	end, err2 := time.Parse(time.DateTime, t.FinishedAt)
+	if err1 != nil || err2 != nil || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// dateTime shortens a stored UTC time to the minute.
+func dateTime(s string) string {
+	if len(s) >= 16 {
+		return s[:16] + " UTC"
+	}
+	return s
+}
+
+// quote renders plain text as a markdown blockquote, keeping line breaks.
+// Answers are what the user typed, not markdown, so anything markdown or
+// HTML would act on is escaped: HashMap<String> stays as written.
+func quote(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "> *(no answer)*\n"
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(strings.TrimRight("> "+escape(line), " ") + "  \n")
+	}
+	return b.String()
+}
+
+// markdownEscaper backslash-escapes the characters that start emphasis,
+// code, links, raw HTML, entities and table cells anywhere in a line.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "&", `\&`, "|", `\|`, "~", `\~`,
+)
+
+// leadingMarker matches what starts a heading, list or thematic break at
+// the beginning of a line.
+var leadingMarker = regexp.MustCompile(`^(\s*)([#+=-]|\d+[.)])`)
+
+// escape makes a line of plain text render as itself.
+func escape(line string) string {
+	line = markdownEscaper.Replace(line)
+	return leadingMarker.ReplaceAllStringFunc(line, func(m string) string {
+		i := len(m) - 1
+		return m[:i] + `\` + m[i:]
+	})
+}
+
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// HTML renders the transcript as a standalone page: the markdown document
+// converted to HTML with the stylesheet inline, so it can be mailed or
+// opened anywhere without fetching anything.
+func (t *Transcript) HTML() ([]byte, error) {
+	var body bytes.Buffer
+	if err := markdown.Convert([]byte(t.Markdown()), &body); err != nil {
+		return nil, fmt.Errorf("render markdown: %w", err)
+	}
+	var out bytes.Buffer
+	err := pageTemplate.Execute(&out, struct {
+		Title string
+		Body  template.HTML
+	}{
+		Title: fmt.Sprintf("%s - %s", t.Skill, dateTime(t.StartedAt)),
+		Body:  template.HTML(body.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("render html: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; background: #f6f6f4; color: #222; font: 16px/1.6 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; }
+main { max-width: 46em; margin: 2em auto; padding: 2em 2.5em; background: #fff; border-radius: 8px; box-shadow: 0 1px 4px rgba(0,0,0,.08); }
+h1 { margin-top: 0; }
+h2 { border-bottom: 1px solid #e4e4e0; padding-bottom: .2em; margin-top: 2em; }
+h3, h4 { color: #555; margin-top: 1.8em; }
+table { border-collapse: collapse; }
+td, th { padding: .2em 1em .2em 0; text-align: left; vertical-align: top; }
+tr > td:first-child { color: #777; }
+thead { display: none; }
+blockquote { margin: 0; padding: .5em 1em; border-left: 4px solid #7aa7d8; background: #f2f6fb; color: #223; }
+code { font: .9em ui-monospace, Menlo, Consolas, monospace; background: #f1f1ee; padding: .1em .3em; border-radius: 3px; }
+pre { background: #f1f1ee; padding: .8em 1em; overflow-x: auto; border-radius: 4px; }
+pre code { background: none; padding: 0; }
+em { color: #666; }
+@media print { body { background: #fff; } main { box-shadow: none; margin: 0; } }
+</style>
+</head>
+<body>
+<main>
+{{.Body}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,126 @@
+package transcript
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"bonk/internal/db"
+)
+
+func session() *db.SessionDetail {
+	return &db.SessionDetail{
+		SkillID:     "hash-maps",
+		StartedAt:   "2026-10-18 14:02:11",
+		FinishedAt:  "2026-10-18 14:14:41",
+		Rating:      3,
+		SelfRating:  4,
+		CoachRating: 3,
+		Exchanges: []db.Exchange{
+			{Turn: 1, Question: "How does a hash map handle **collisions**?", Facet: "collisions", QuestionType: "conceptual", Answer: "Chaining.\nOr open addressing.", CoachRating: 2},
+			{Turn: 2, Question: "What is the `load factor`?", Facet: "resizing", Answer: "(gave up, answer revealed)", Hints: 2, Revealed: true, CoachRating: 3},
+			{Turn: 3, Question: "Solid overall. <b>Work on resizing.</b>", Final: true},
+		},
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	tr := New(session())
+	tr.Feedback = "Be more concise."
+	md := tr.Markdown()
+
+	for _, want := range []string{
+		"# Hash Maps\n",
+		"| Rating | 3/4 (you 4, coach 3) |",
+		"| Duration | 13 minutes |",
+		"| Coach rating by turn | 2 → 3 |",
+		"### Turn 1 · collisions · conceptual · coach 2/4\n\n**Coach**\n\nHow does a hash map handle **collisions**?",
+		"> Chaining.  \n> Or open addressing.  \n",
+		"> (gave up, answer revealed)  \n\n*hints: 2*",
+		"## Assessment\n\nSolid overall.",
+		"## AI Feedback\n\nBe more concise.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if len(tr.Turns) != 2 {
+		t.Errorf("turns = %d, want the assessment left out", len(tr.Turns))
+	}
+}
+
+func TestMarkdownPhases(t *testing.T) {
+	s := session()
+	s.Exchanges[0].Phase = "requirements"
+	s.Exchanges[1].Phase = "api"
+	md := New(s).Markdown()
+	if !strings.Contains(md, "### Requirements\n\n#### Turn 1") || !strings.Contains(md, "### API Design\n\n#### Turn 2") {
+		t.Errorf("phase headings missing:\n%s", md)
+	}
+}
+
+func TestHTMLIsSelfContained(t *testing.T) {
+	page, err := New(session()).Render("html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(page)
+	for _, want := range []string{"<!DOCTYPE html>", "<style>", "<strong>collisions</strong>", "<code>load factor</code>", "<table>", "<blockquote>"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html missing %q", want)
+		}
+	}
+	if regexp.MustCompile(`(?i)(src|href)\s*=|<link|<script|@import|url\(`).MatchString(html) {
+		t.Errorf("html references external assets:\n%s", html)
+	}
+	// Raw HTML from the model is not passed through.
+	if strings.Contains(html, "<b>") {
+		t.Errorf("html kept raw markup from a coach message")
+	}
+}
+
+func TestHTMLKeepsAnswersAsTyped(t *testing.T) {
+	s := session()
+	s.Exchanges[0].Answer = "Use a HashMap<String, List<Integer>> & dedupe *keys*\n# not a heading\n- not a list"
+	page, err := New(s).Render("html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(page)
+	for _, want := range []string{
+		"HashMap&lt;String, List&lt;Integer&gt;&gt; &amp; dedupe *keys*",
+		"# not a heading",
+		"- not a list",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html missing %q:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<h1>#") || strings.Contains(html, "<li>not a list") {
+		t.Errorf("answer was rendered as markup:\n%s", html)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	b, err := New(session()).Render("json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Transcript
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Skill != "Hash Maps" || got.Rating != 3 || len(got.Turns) != 2 || got.Assessment == "" {
+		t.Errorf("decoded = %+v", got)
+	}
+	if turn := got.Turns[1]; turn.Hints != 2 || !turn.Revealed || turn.Facet != "resizing" {
+		t.Errorf("turn 2 = %+v", turn)
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, err := New(session()).Render("pdf"); err == nil {
+		t.Error("Render(pdf) succeeded")
+	}
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"bonk/internal/achievements"
+)
+
+var (
+	unlockedStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	unlockedNameStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("252")).Bold(true)
+)
+
+// maxAnnounced caps the achievements listed at once; a first check on a
+// long history can unlock many.
+const maxAnnounced = 5
+
+// unlockAchievements checks for achievements the session just finished
+// has earned. It reports whether there are any to announce.
+func (m *Model) unlockAchievements() bool {
+	newly, err := m.db.UnlockAchievements(m.clock, m.sessionID)
+	if err != nil || len(newly) == 0 {
+		return false
+	}
+	m.unlocked = newly
+	return true
+}
+
+// handleUnlockedKey dismisses the announcement shown after rating: q or
+// esc stops, any other key goes on to the next drill.
+func (m Model) handleUnlockedKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if len(m.unlocked) == 0 {
+		return m, nil, false
+	}
+	if msg.String() == "q" || msg.Type == tea.KeyEsc {
+		m.continueToNext = false
+		m.quitting = true
+	}
+	return m, tea.Quit, true
+}
+
+// renderUnlocked announces newly unlocked achievements.
+func renderUnlocked(unlocked []achievements.Achievement) string {
+	var b strings.Builder
+	title := "Achievement unlocked!"
+	if len(unlocked) > 1 {
+		title = fmt.Sprintf("%d achievements unlocked!", len(unlocked))
+	}
+	b.WriteString(unlockedStyle.Render("★ "+title) + "\n\n")
+	for i, a := range unlocked {
+		if i == maxAnnounced {
+			b.WriteString(helpStyle.Render(fmt.Sprintf("  and %d more - bonk achievements", len(unlocked)-maxAnnounced)) + "\n")
+			break
+		}
+		b.WriteString("  " + unlockedNameStyle.Render(a.Name) + helpStyle.Render("  "+a.Description) + "\n")
+	}
+	return b.String() + "\n"
+}
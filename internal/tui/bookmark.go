@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"bonk/internal/db"
+)
+
+var bookmarkStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+
+func newNoteInput(width int) textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "note (optional)"
+	ti.CharLimit = 1000
+	ti.Prompt = "★ "
+	ti.PromptStyle = bookmarkStyle
+	ti.Width = max(20, width-6)
+	ti.Focus()
+	return ti
+}
+
+// handleBookmarkKey handles ctrl+s, which bookmarks the coach's current
+// message and asks for a note, and the keys typed into that note.
+func (m Model) handleBookmarkKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.noting {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.saveBookmark()
+		case tea.KeyEsc:
+			m.noting = false
+		default:
+			var cmd tea.Cmd
+			m.noteInput, cmd = m.noteInput.Update(msg)
+			return m, cmd, true
+		}
+		return m, nil, true
+	}
+	if msg.Type != tea.KeyCtrlS || m.lastResp == nil {
+		return m, nil, false
+	}
+	m.noting = true
+	m.bookmarked = false
+	m.noteInput = newNoteInput(m.mainContentWidth())
+	return m, textinput.Blink, true
+}
+
+// saveBookmark stores the bookmark with the note typed so far.
+func (m *Model) saveBookmark() {
+	m.noting = false
+	err := m.db.SaveNote(db.Note{
+		SkillID:   m.skill.ID,
+		SessionID: m.sessionID,
+		Turn:      m.turn,
+		Excerpt:   m.lastResp.Text,
+		Text:      m.noteInput.Value(),
+	})
+	m.bookmarked = err == nil
+}
+
+// renderBookmark shows the note being typed, or that the current message
+// is bookmarked.
+func (m Model) renderBookmark() string {
+	switch {
+	case m.noting:
+		return m.noteInput.View() + "\n" + helpStyle.Render("enter save • esc cancel") + "\n"
+	case m.bookmarked:
+		return bookmarkStyle.Render("★ bookmarked") + helpStyle.Render("  bonk notes "+m.skill.ID) + "\n\n"
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	streakStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+	freezeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("117"))
+	goalStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("78"))
+)
+
+// maxGoalBar is the largest weekly goal drawn as a bar of boxes.
+const maxGoalBar = 14
+
+// renderFreezes shows the banked streak freezes as snowflakes.
+func renderFreezes(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return freezeStyle.Render(" " + strings.Repeat("❄", n))
+}
+
+// renderGoals is the welcome screen line for the weekly goal and a streak
+// that ends unless the user drills today.
+func (m Model) renderGoals() string {
+	var line string
+	if m.weeklyGoal > 0 {
+		line = helpStyle.Render("  this week: ")
+		if m.weeklyGoal <= maxGoalBar {
+			done := min(m.weekCount, m.weeklyGoal)
+			line += goalStyle.Render(strings.Repeat("■", done)) + helpStyle.Render(strings.Repeat("□", m.weeklyGoal-done)) + " "
+		}
+		label := fmt.Sprintf("%d/%d sessions", m.weekCount, m.weeklyGoal)
+		if m.weekCount >= m.weeklyGoal {
+			label += " - goal met"
+		}
+		line += helpStyle.Render(label)
+	}
+	if m.streak.AtRisk() {
+		risk := "drill today to keep your streak"
+		if m.streak.Freezes > 0 {
+			risk += " (or spend a freeze)"
+		}
+		if line == "" {
+			line = "  "
+		} else {
+			line += helpStyle.Render("  •  ")
+		}
+		line += streakStyle.Render(risk)
+	}
+	return line
+}
@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"bonk/internal/voice"
+)
+
+// autoSubmitSeconds is the cancel window before a hands-free answer is sent.
+const autoSubmitSeconds = 3
+
+// Hands-free messages
+type speechDoneMsg struct {
+	turn int
+}
+
+type listenStartedMsg struct {
+	listener *voice.Listener
+	err      error
+}
+
+type listenDoneMsg struct {
+	audioPath string
+	err       error
+}
+
+type autoSubmitTickMsg struct {
+	seq int
+}
+
+func (m Model) handsFreeEnabled() bool {
+	return m.handsFree != nil && m.voiceBackend.CanRecord()
+}
+
+// awaitSpeechEnd reports when the coach has finished speaking this turn's
+// question, so listening can start without picking up the coach's voice.
+func (m Model) awaitSpeechEnd() tea.Cmd {
+	if !m.handsFreeEnabled() {
+		return nil
+	}
+	speech, turn := m.speechProc, m.turn
+	return func() tea.Msg {
+		if speech != nil {
+			<-speech.Done()
+		}
+		return speechDoneMsg{turn: turn}
+	}
+}
+
+func (m Model) startListening() tea.Cmd {
+	stt, cfg := m.voiceBackend.STT, *m.handsFree
+	return func() tea.Msg {
+		l, err := voice.Listen(stt, cfg)
+		return listenStartedMsg{listener: l, err: err}
+	}
+}
+
+func waitForUtterance(l *voice.Listener) tea.Cmd {
+	return func() tea.Msg {
+		path, err := l.Wait()
+		return listenDoneMsg{audioPath: path, err: err}
+	}
+}
+
+// transcribe converts a finished recording to text and delivery metrics.
+// auto marks hands-free answers, which are submitted after a cancel window.
+func transcribe(stt voice.STT, audioPath string, auto bool) tea.Cmd {
+	return func() tea.Msg {
+		text, err := stt.Transcribe(audioPath)
+		var delivery *voice.Delivery
+		if err == nil {
+			delivery = voice.AnalyzeDelivery(text, audioPath)
+		}
+		os.Remove(audioPath) // cleanup temp file
+		return transcriptionMsg{text: text, delivery: delivery, auto: auto, err: err}
+	}
+}
+
+func autoSubmitTick(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return autoSubmitTickMsg{seq: seq}
+	})
+}
+
+// handleSpeechDone starts listening once the coach stops talking, unless the
+// user has already started answering another way.
+func (m Model) handleSpeechDone(msg speechDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.turn != m.turn || m.state != stateDrilling || m.listening || m.recording || m.transcribing {
+		return m, nil
+	}
+	if strings.TrimSpace(m.textarea.Value()) != "" {
+		return m, nil
+	}
+	m.speechProc = nil
+	return m, m.startListening()
+}
+
+func (m Model) handleListenStarted(msg listenStartedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		// Fall back to manual recording/typing for this turn
+		return m, nil
+	}
+	if m.state != stateDrilling {
+		msg.listener.Cancel()
+		return m, nil
+	}
+	m.listening = true
+	m.listener = msg.listener
+	return m, waitForUtterance(msg.listener)
+}
+
+func (m Model) handleListenDone(msg listenDoneMsg) (tea.Model, tea.Cmd) {
+	wasListening := m.listening
+	m.listening = false
+	m.listener = nil
+	if msg.err != nil || !wasListening {
+		if errors.Is(msg.err, voice.ErrNoSpeech) {
+			m.voiceNotice = "no speech heard, press space to record"
+		}
+		return m, nil
+	}
+	m.transcribing = true
+	return m, transcribe(m.voiceBackend.STT, msg.audioPath, true)
+}
+
+// startAutoSubmit opens the cancel window for a hands-free answer.
+func (m *Model) startAutoSubmit() tea.Cmd {
+	m.autoSubmitSeq++
+	m.autoSubmitIn = autoSubmitSeconds
+	return autoSubmitTick(m.autoSubmitSeq)
+}
+
+func (m *Model) cancelAutoSubmit() {
+	m.autoSubmitSeq++
+	m.autoSubmitIn = 0
+}
+
+func (m Model) handleAutoSubmitTick(msg autoSubmitTickMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.autoSubmitSeq || m.autoSubmitIn == 0 || m.state != stateDrilling {
+		return m, nil
+	}
+	m.autoSubmitIn--
+	if m.autoSubmitIn > 0 {
+		return m, autoSubmitTick(msg.seq)
+	}
+	return m.submitAnswer()
+}
+
+// handleHandsFreeKey lets keys interrupt hands-free turn taking. It returns
+// handled=false when the key should continue through normal drill handling.
+func (m Model) handleHandsFreeKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.autoSubmitIn > 0 {
+		// Any key cancels the pending send; esc only cancels.
+		m.cancelAutoSubmit()
+		if msg.Type == tea.KeyEsc {
+			return m, nil, true
+		}
+	}
+	if m.listening {
+		switch {
+		case msg.Type == tea.KeyEsc:
+			m.listener.Cancel()
+			m.listening = false
+			return m, nil, true
+		case msg.String() == " ":
+			// Done talking - transcribe now without waiting for silence
+			m.listener.Finish()
+			return m, nil, true
+		default:
+			// Typing takes over from listening
+			m.listener.Cancel()
+			m.listening = false
+		}
+	}
+	return m, nil, false
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"bonk/internal/db"
+	"bonk/internal/llm"
+)
+
+// Help requests, kept in pendingHelp while in flight.
+const (
+	helpHint   = "hint"
+	helpReveal = "reveal"
+)
+
+// revealedAnswer stands in for the answer to a question given up on.
+const revealedAnswer = "(gave up, answer revealed)"
+
+// handleHelpKey asks for a hint on ctrl+t and gives up on the question on
+// ctrl+r, both only while the answer box is empty. They are chords so an
+// answer can start with any letter.
+func (m Model) handleHelpKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if msg.Type != tea.KeyCtrlT && msg.Type != tea.KeyCtrlR {
+		return m, nil, false
+	}
+	if strings.TrimSpace(m.textarea.Value()) != "" || m.recording || m.transcribing || m.notebookFocused {
+		return m, nil, true
+	}
+	switch msg.Type {
+	case tea.KeyCtrlT:
+		if len(m.hints) >= llm.MaxHints {
+			m.voiceNotice = "no hints left - ctrl+r reveals the answer"
+			return m, nil, true
+		}
+		m.pendingHelp = helpHint
+	case tea.KeyCtrlR:
+		m.pendingHelp = helpReveal
+		m.pendingAnswer = revealedAnswer
+		m.shareNotebook()
+	}
+	if m.speechProc != nil {
+		m.speechProc.Stop()
+		m.speechProc = nil
+	}
+	m.cancelAutoSubmit()
+	m.voiceNotice = ""
+	m.state = stateLoading
+	return m, m.askForHelp(m.pendingHelp), true
+}
+
+// askForHelp sends a hint or reveal request in the background, like
+// getCoachResponse.
+func (m *Model) askForHelp(kind string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelRequest = cancel
+	conv := m.conversation
+	return func() tea.Msg {
+		defer cancel()
+		var resp *llm.Response
+		var err error
+		if kind == helpHint {
+			resp, err = conv.Hint(ctx)
+		} else {
+			resp, err = conv.Reveal(ctx)
+		}
+		return coachResponseMsg{resp: resp, err: err}
+	}
+}
+
+// showHint adds a hint to the current question, which stays open.
+func (m *Model) showHint(resp *llm.Response) tea.Cmd {
+	m.pendingHelp = ""
+	m.hints = append(m.hints, resp.Text)
+	m.help.Hints++
+	m.recordUsage(resp)
+	m.state = stateDrilling
+	if m.voiceBackend.CanSpeak() {
+		m.speechProc = m.voiceBackend.TTS.Speak(resp.Text)
+	}
+	return m.awaitSpeechEnd()
+}
+
+// renderHints shows the hints given on a question, most direct last.
+func renderHints(hints []string, width int) string {
+	var b strings.Builder
+	for i, h := range hints {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Hint %d/%d (%s)", i+1, llm.MaxHints, llm.HintLevels[i])) + "\n")
+		b.WriteString(renderMarkdown(h, width) + "\n")
+	}
+	return b.String()
+}
+
+// helpNote tells the user on the rating screen what the help they took
+// costs; empty when they took none.
+func helpNote(h db.Help) string {
+	var parts []string
+	if h.Hints > 0 {
+		parts = append(parts, countOf(h.Hints, "hint"))
+	}
+	if h.Reveals > 0 {
+		parts = append(parts, countOf(h.Reveals, "answer")+" revealed")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Help taken: %s. A passing rating schedules the next review %.0f%% sooner.",
+		strings.Join(parts, ", "), (1-h.IntervalFactor())*100)
+}
+
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func drillingModel() Model {
+	ta := textarea.New()
+	ta.Focus()
+	return Model{state: stateDrilling, textarea: ta}
+}
+
+func TestLettersStartAnAnswer(t *testing.T) {
+	for _, key := range []string{"h", "r"} {
+		updated, _ := drillingModel().Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m := updated.(Model)
+		if m.textarea.Value() != key {
+			t.Errorf("typing %q: answer box = %q", key, m.textarea.Value())
+		}
+		if m.state != stateDrilling || m.pendingHelp != "" {
+			t.Errorf("typing %q asked for help (%q)", key, m.pendingHelp)
+		}
+	}
+}
+
+func TestHelpKeysNeedAnEmptyAnswer(t *testing.T) {
+	m := drillingModel()
+	m.textarea.SetValue("Redis")
+	for _, key := range []tea.KeyType{tea.KeyCtrlT, tea.KeyCtrlR} {
+		updated, _ := m.Update(tea.KeyMsg{Type: key})
+		got := updated.(Model)
+		if got.pendingHelp != "" || got.textarea.Value() != "Redis" {
+			t.Errorf("%v with an answer typed: help %q, answer %q", key, got.pendingHelp, got.textarea.Value())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"bonk/internal/pacing"
+)
+
+// notebookWidth is the sidebar width while the design notebook is open.
+const notebookWidth = 48
+
+func newNotePad() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "notes for this phase..."
+	ta.CharLimit = 4000
+	ta.ShowLineNumbers = false
+	ta.FocusedStyle.Base = lipgloss.NewStyle()
+	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	ta.FocusedStyle.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	ta.BlurredStyle.Base = lipgloss.NewStyle()
+	ta.BlurredStyle.CursorLine = lipgloss.NewStyle()
+	ta.Prompt = ""
+	ta.SetWidth(notebookWidth - 4)
+	return ta
+}
+
+// handleNotebookKey handles ctrl+o, which cycles the notebook pane from
+// hidden to focused and back, and every key while the pane has focus.
+func (m Model) handleNotebookKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.doc == nil {
+		return m, nil, false
+	}
+	if msg.Type == tea.KeyCtrlO {
+		if m.notebookFocused {
+			m.notebookOpen = false
+			m.blurNotebook()
+		} else {
+			if !m.notebookOpen {
+				m.notebookOpen = true
+				m.openSection(m.currentSection())
+			}
+			m.notebookFocused = true
+			m.textarea.Blur()
+			m.notePad.Focus()
+		}
+		m.syncLayout()
+		return m, nil, true
+	}
+	if !m.notebookFocused {
+		return m, nil, false
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.blurNotebook()
+		return m, nil, true
+	case tea.KeyPgUp, tea.KeyPgDown:
+		delta := 1
+		if msg.Type == tea.KeyPgUp {
+			delta = -1
+		}
+		_, pos, _ := pacing.Lookup(m.noteSection)
+		next := (pos - 1 + delta + len(pacing.Phases)) % len(pacing.Phases)
+		m.openSection(pacing.Phases[next].ID)
+		return m, nil, true
+	}
+	var cmd tea.Cmd
+	m.notePad, cmd = m.notePad.Update(msg)
+	m.doc.Set(m.noteSection, m.notePad.Value())
+	return m, cmd, true
+}
+
+func (m *Model) blurNotebook() {
+	m.notebookFocused = false
+	m.notePad.Blur()
+	m.textarea.Focus()
+}
+
+// currentSection is the phase the interview is in, or the first phase
+// before the coach has reported one.
+func (m Model) currentSection() string {
+	if _, _, ok := pacing.Lookup(m.phase); ok {
+		return m.phase
+	}
+	return pacing.Phases[0].ID
+}
+
+func (m *Model) openSection(phase string) {
+	m.noteSection = phase
+	m.notePad.SetValue(m.doc.Get(phase))
+}
+
+// shareNotebook hands the current notes to the coach with the next answer
+// and saves them with the session.
+func (m *Model) shareNotebook() {
+	if m.doc == nil {
+		return
+	}
+	m.conversation.SetNotes(m.doc.Context())
+	m.saveNotebook()
+}
+
+func (m *Model) saveNotebook() {
+	if m.doc == nil || m.sessionID == "" {
+		return
+	}
+	m.db.SaveDesignDoc(m.sessionID, m.doc.Marshal())
+}
+
+func (m Model) renderNotebook() string {
+	var b strings.Builder
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+
+	_, pos, _ := pacing.Lookup(m.noteSection)
+	b.WriteString(labelStyle.Render("notebook") + "\n")
+	b.WriteString(sectionStyle.Render(pacing.Title(m.noteSection)) +
+		labelStyle.Render(fmt.Sprintf(" %d/%d", pos, len(pacing.Phases))) + "\n\n")
+	b.WriteString(m.notePad.View() + "\n\n")
+
+	for _, p := range pacing.Phases {
+		mark := "○"
+		if m.doc.Get(p.ID) != "" {
+			mark = "●"
+		}
+		line := mark + " " + p.Title
+		if p.ID == m.noteSection {
+			b.WriteString(sectionStyle.Render(line) + "\n")
+		} else {
+			b.WriteString(labelStyle.Render(line) + "\n")
+		}
+	}
+
+	help := "ctrl+o edit"
+	if m.notebookFocused {
+		help = "pgup/pgdn section • esc answer • ctrl+o hide"
+	}
+	b.WriteString("\n" + helpStyle.Render(help))
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bonk/internal/skills"
+)
+
+// renderPlan is the welcome screen line for today's share of the study
+// plan, with anything left over from earlier days.
+func (m Model) renderPlan() string {
+	if m.plan == nil {
+		return ""
+	}
+	today := m.clock.Day(time.Now())
+	var items []string
+	carried := 0
+	for _, day := range m.plan.Days {
+		if day.Date > today {
+			break
+		}
+		for _, item := range day.Items {
+			switch {
+			case day.Date == today && item.Done:
+				items = append(items, goalStyle.Render("✓ "+skillDisplayName(item.SkillID)))
+			case day.Date == today:
+				items = append(items, skillDisplayName(item.SkillID))
+			case !item.Done:
+				carried++
+			}
+		}
+	}
+
+	label := fmt.Sprintf("  today's plan (%s to %s): ", daysLeft(today, m.plan.Until), m.plan.Until)
+	line := helpStyle.Render(label)
+	if len(items) == 0 {
+		line += helpStyle.Render("rest day")
+	} else {
+		line += strings.Join(items, helpStyle.Render(" • "))
+	}
+	if carried > 0 {
+		line += streakStyle.Render(fmt.Sprintf("  +%d carried over", carried))
+	}
+	return line
+}
+
+// daysLeft counts the study days from today through until, inclusive.
+func daysLeft(today, until string) string {
+	from, err1 := time.Parse(time.DateOnly, today)
+	to, err2 := time.Parse(time.DateOnly, until)
+	if err1 != nil || err2 != nil {
+		return "?"
+	}
+	n := int(to.Sub(from).Hours()/24) + 1
+	if n == 1 {
+		return "last day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+func skillDisplayName(id string) string {
+	if s := skills.Get(id); s != nil {
+		return s.Name
+	}
+	return id
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"bonk/internal/db"
+	"bonk/internal/recall"
+	"bonk/internal/skills"
+	"bonk/internal/streak"
+)
+
+// recallDeckSize is how many cards an offline session asks.
+const recallDeckSize = 8
+
+// RecallModel is the offline drill: self-graded cards built from the
+// skill's facets, its guide and past coach corrections. It needs no API.
+type RecallModel struct {
+	db             *db.DB
+	skill          *skills.Skill
+	clock          streak.Clock
+	cards          []recall.Card
+	index          int
+	revealed       bool
+	grades         []int
+	sessionID      string
+	rating         int // set once every card is graded
+	width          int
+	err            error
+	continueToNext bool
+}
+
+func NewRecallModel(database *db.DB, skill *skills.Skill, clock streak.Clock) RecallModel {
+	past, _ := database.GetAssessments(skill.ID, 5)
+	corrections := make([]recall.Correction, 0, len(past))
+	for _, a := range past {
+		corrections = append(corrections, recall.Correction{Date: formatDate(a.FinishedAt), Assessment: a.Assessment})
+	}
+	cards := recall.Cards(skill, skills.GetGuide(skill.ID), corrections)
+	return RecallModel{
+		db:    database,
+		skill: skill,
+		clock: clock,
+		cards: recall.Deck(cards, recallDeckSize, rand.New(rand.NewSource(time.Now().UnixNano()))),
+		width: 80,
+	}
+}
+
+func (m RecallModel) Init() tea.Cmd {
+	database, skillID := m.db, m.skill.ID
+	return func() tea.Msg {
+		id, err := database.CreateRecallSession(skillID)
+		return sessionCreatedMsg{sessionID: id, err: err}
+	}
+}
+
+func (m RecallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case sessionCreatedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		m.sessionID = msg.sessionID
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC || msg.Type == tea.KeyEsc || msg.String() == "q" {
+			return m, tea.Quit
+		}
+		switch {
+		case len(m.cards) == 0:
+			// Nothing to reveal or grade; only quitting does anything.
+		case m.rating > 0:
+			if msg.Type == tea.KeyEnter || msg.String() == "n" {
+				m.continueToNext = true
+				return m, tea.Quit
+			}
+		case !m.revealed:
+			if msg.Type == tea.KeyEnter || msg.Type == tea.KeySpace {
+				m.revealed = true
+			}
+		case m.sessionID != "":
+			switch msg.String() {
+			case "1", "2", "3", "4":
+				return m.grade(int(msg.String()[0] - '0'))
+			}
+		}
+	}
+	return m, nil
+}
+
+// grade records the self grade for the current card and moves on,
+// finishing the session after the last card.
+func (m RecallModel) grade(g int) (tea.Model, tea.Cmd) {
+	if m.index >= len(m.cards) {
+		return m, nil
+	}
+	card := m.cards[m.index]
+	m.db.SaveExchange(m.sessionID, db.Exchange{
+		Turn:         m.index + 1,
+		Question:     card.Front,
+		QuestionType: "recall",
+		Facet:        card.Facet,
+		Answer:       fmt.Sprintf("(self-graded %d/4)", g),
+		Struggled:    g <= 2,
+	})
+	m.grades = append(m.grades, g)
+	m.index++
+	m.revealed = false
+	if m.index < len(m.cards) {
+		return m, nil
+	}
+
+	m.rating = recall.Rating(m.grades)
+	if err := m.db.FinishSession(m.clock, m.sessionID, db.Ratings{Self: m.rating, Final: m.rating}, ""); err != nil {
+		m.err = err
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m RecallModel) View() string {
+	if m.err != nil {
+		return fmt.Sprintf("Error: %v\n\nPress any key to exit.", m.err)
+	}
+	width := max(40, min(m.width, 100)) - 4
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("bonk") + "  " + domainStyle.Render("offline recall"))
+	if m.rating == 0 && len(m.cards) > 0 {
+		b.WriteString("  " + helpStyle.Render(fmt.Sprintf("card %d/%d", m.index+1, len(m.cards))))
+	}
+	b.WriteString("\n\n")
+
+	switch {
+	case len(m.cards) == 0:
+		b.WriteString("Nothing to recall for " + m.skill.Name + " yet.\n\n")
+		b.WriteString(helpStyle.Render("q quit"))
+
+	case m.rating > 0:
+		b.WriteString(skillRevealStyle.Render(m.skill.Name) + "  ")
+		b.WriteString(domainStyle.Render(m.skill.Domain) + "\n\n")
+		b.WriteString(fmt.Sprintf("Rated %s from %d cards; scheduled like any other drill.\n\n",
+			ratingGlyph(m.rating)+fmt.Sprintf(" %d/4", m.rating), len(m.grades)))
+		b.WriteString(helpStyle.Render("enter next skill • q quit"))
+
+	default:
+		card := m.cards[m.index]
+		b.WriteString(helpStyle.Render(card.Source) + "\n")
+		b.WriteString(renderMarkdown(card.Front, width) + "\n")
+		if !m.revealed {
+			b.WriteString(helpStyle.Render("space reveal • q quit"))
+			break
+		}
+		b.WriteString(dividerStyle.Render(strings.Repeat("─", min(50, width))) + "\n")
+		b.WriteString(lipgloss.NewStyle().PaddingLeft(2).Render(renderMarkdown(card.Back, width-2)) + "\n\n")
+		b.WriteString(ratingStyle.Render("How well did you recall it?") + "\n\n")
+		b.WriteString("  " + ratingKeyStyle.Render("[1]") + ratingOptionStyle.Render(" Again  "))
+		b.WriteString(ratingKeyStyle.Render("[2]") + ratingOptionStyle.Render(" Hard  "))
+		b.WriteString(ratingKeyStyle.Render("[3]") + ratingOptionStyle.Render(" Good  "))
+		b.WriteString(ratingKeyStyle.Render("[4]") + ratingOptionStyle.Render(" Easy") + "\n\n")
+		b.WriteString(helpStyle.Render("1-4 grade • q quit"))
+	}
+	return b.String()
+}
+
+func (m RecallModel) ShouldContinue() bool {
+	return m.continueToNext
+}
@@ -0,0 +1,24 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"bonk/internal/skills"
+)
+
+func TestRecallEmptyDeck(t *testing.T) {
+	var m tea.Model = RecallModel{skill: &skills.Skill{Name: "Hash Maps"}, sessionID: "s", width: 80}
+	for _, key := range []tea.KeyMsg{{Type: tea.KeySpace}, {Type: tea.KeyRunes, Runes: []rune("1")}} {
+		m, _ = m.Update(key)
+	}
+	got := m.(RecallModel)
+	if got.rating != 0 || len(got.grades) != 0 {
+		t.Errorf("empty deck graded: rating %d, grades %v", got.rating, got.grades)
+	}
+	if view := got.View(); !strings.Contains(view, "Nothing to recall") {
+		t.Errorf("view = %q", view)
+	}
+}
@@ -1,22 +1,29 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
 
+	"bonk/internal/achievements"
 	"bonk/internal/db"
+	"bonk/internal/estimate"
 	"bonk/internal/llm"
+	"bonk/internal/notebook"
+	"bonk/internal/pacing"
 	"bonk/internal/skills"
+	"bonk/internal/streak"
 	"bonk/internal/voice"
 )
 
@@ -74,6 +81,9 @@ var (
 	ratingKeyStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color("212"))
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("196"))
 )
 
 type state int
@@ -83,27 +93,9 @@ const (
 	stateDrilling
 	stateRating
 	stateLoading
+	stateError // coach request failed; the answer is kept for retry
 )
 
-// Phase name mappings for system-design-practical
-var phaseNames = map[string]string{
-	"requirements": "Requirements",
-	"entities":     "Core Entities",
-	"api":          "API Design",
-	"dataflow":     "Data Flow",
-	"highlevel":    "High-Level Design",
-	"deepdives":    "Deep Dives",
-}
-
-var phaseOrder = map[string]int{
-	"requirements": 1,
-	"entities":     2,
-	"api":          3,
-	"dataflow":     4,
-	"highlevel":    5,
-	"deepdives":    6,
-}
-
 type Model struct {
 	db           *db.DB
 	skill        *skills.Skill
@@ -113,17 +105,37 @@ type Model struct {
 	state             state
 	turn              int
 	maxTurns          int
+	practicalMaxTurns int
+	ratingBlend       db.RatingBlend
 	lastResp          *llm.Response
-	phase             string // current phase for system-design-practical
+	phase             string          // current phase for system-design-practical
+	phases            *pacing.Tracker // time and turns per phase, system-design-practical only
+	doc               *notebook.Doc   // design notebook, system-design-practical only
+	notePad           textarea.Model  // editor for the open notebook section
+	noteSection       string
+	notebookOpen      bool
+	notebookFocused   bool
 	history           []exchange
 	textarea          textarea.Model
 	viewport          viewport.Model
 	spinner           spinner.Model
 	width             int
 	height            int
-	err               error
+	err               error // fatal; the drill exits
+	requestErr        error // failed coach request, recoverable in stateError
+	cancelRequest     context.CancelFunc
+	pendingAnswer     string   // submitted answer awaiting the coach's reply
+	pendingHelp       string   // helpHint or helpReveal while one is in flight
+	hints             []string // hints given on the current question
+	help              db.Help  // hints and reveals over the session
+	noting            bool     // typing a note for a bookmark
+	noteInput         textinput.Model
+	bookmarked        bool             // the coach's current message is bookmarked
+	unsavedUsage      []db.UsageRecord // usage that arrived before the session was created
 	quitting          bool
 	continueToNext    bool
+	unlocked          []achievements.Achievement // announced after rating
+	clock             streak.Clock
 	showDebug         bool
 	historyCtx        string
 	difficulty        string
@@ -131,15 +143,27 @@ type Model struct {
 	llmRating         int // LLM's rating of user performance (1-4, 0 if not provided)
 	selectedDomain    string
 	allowDomainPicker bool
-	voiceEnabled      bool
+	voiceBackend      *voice.Backend // nil when voice mode is off
 	recording         bool
 	transcribing      bool
 	recordingProc     *voice.Recording
 	speechProc        *voice.SpeechProcess
+	pendingDelivery   *voice.Delivery // metrics for the transcribed answer in the textarea
+	voiceNotice       string          // last voice problem, shown next to the answer box
+
+	// Hands-free turn taking
+	handsFree     *voice.VADConfig // nil unless hands-free mode is on
+	listener      *voice.Listener
+	listening     bool
+	autoSubmitIn  int // seconds left before a transcribed answer is sent
+	autoSubmitSeq int // invalidates stale countdown ticks
 
 	// Welcome screen stats
 	totalSessions  int
-	currentStreak  int
+	streak         streak.Status
+	weekCount      int // sessions this week
+	weeklyGoal     int
+	plan           *db.Plan // active study plan, nil without one
 	dueCount       int
 	dueWeekCount   int
 	newSkillCount  int
@@ -148,11 +172,14 @@ type Model struct {
 	recentRatings  []int
 	recentSessions []db.RecentSession
 	weakFacets     []db.FacetStats
+	deliveryTrend  []db.DeliverySummary
 }
 
 type exchange struct {
 	question string
+	hints    []string
 	answer   string
+	check    *estimate.Result // estimation drills only
 }
 
 // Messages
@@ -172,11 +199,32 @@ type recordingStartedMsg struct {
 }
 
 type transcriptionMsg struct {
-	text string
-	err  error
+	text     string
+	delivery *voice.Delivery
+	auto     bool // hands-free answer, submit after the cancel window
+	err      error
 }
 
-func NewModel(database *db.DB, skill *skills.Skill, allowDomainPicker bool, voiceEnabled bool) Model {
+// Options configures a drill session.
+type Options struct {
+	// AllowDomainPicker shows the domain chooser on the welcome screen.
+	AllowDomainPicker bool
+	// Voice is the detected voice backend, nil when voice mode is off.
+	Voice *voice.Backend
+	// HandsFree enables voice activity detection for turn taking.
+	HandsFree *voice.VADConfig
+	// MaxTurns and PracticalMaxTurns cap drill length; zero uses 20 and 40.
+	MaxTurns          int
+	PracticalMaxTurns int
+	// RatingBlend combines the self and coach ratings; zero uses db.DefaultBlend.
+	RatingBlend db.RatingBlend
+	// Clock decides which day a session counts toward for streaks and goals.
+	Clock streak.Clock
+	// WeeklyGoal is the sessions a week to aim for; zero hides the goal.
+	WeeklyGoal int
+}
+
+func NewModel(database *db.DB, skill *skills.Skill, opts Options) Model {
 	ta := textarea.New()
 	ta.Placeholder = ""
 	ta.CharLimit = 2000
@@ -198,18 +246,31 @@ func NewModel(database *db.DB, skill *skills.Skill, allowDomainPicker bool, voic
 
 	// Fetch welcome stats
 	totalSessions, _ := database.GetTotalSessions()
-	currentStreak, _, _ := database.GetStreak()
+	streakStatus, _ := database.GetStreak(opts.Clock)
+	weekCount, _ := database.GetWeekSessionCount(opts.Clock)
+	plan, _ := database.GetActivePlan(opts.Clock.Day(time.Now()))
 	dueCount, _ := database.GetDueCount()
 	dueWeekCount, _ := database.GetDueThisWeek()
 	newSkillCount := len(database.GetNewSkills(skills.ListIDs()))
 	avgRating, _, _ := database.GetOverallAvgRating()
-	todayCount, _ := database.GetTodaySessionCount()
+	todayCount, _ := database.GetTodaySessionCount(opts.Clock)
 	recentRatings, _ := database.GetRecentRatings(10)
 	recentSessions, _ := database.GetRecentSessions(5)
 	weakFacets, _ := database.GetWeakFacets(2)
+	deliveryTrend, _ := database.GetDeliveryTrend(10)
+
+	if opts.MaxTurns <= 0 {
+		opts.MaxTurns = 20
+	}
+	if opts.PracticalMaxTurns <= 0 {
+		opts.PracticalMaxTurns = 40
+	}
+	if opts.RatingBlend.Mode == "" {
+		opts.RatingBlend = db.DefaultBlend
+	}
 
 	defaultDomain := ""
-	if allowDomainPicker && skill != nil {
+	if opts.AllowDomainPicker && skill != nil {
 		defaultDomain = skill.Domain
 	}
 
@@ -218,16 +279,23 @@ func NewModel(database *db.DB, skill *skills.Skill, allowDomainPicker bool, voic
 		skill:             skill,
 		state:             stateWelcome,
 		turn:              0,
-		maxTurns:          20, // Default; overridden per-domain in startDrill
+		maxTurns:          opts.MaxTurns, // overridden per-domain in startDrill
+		practicalMaxTurns: opts.PracticalMaxTurns,
+		ratingBlend:       opts.RatingBlend,
+		clock:             opts.Clock,
 		showDebug:         false,
-		allowDomainPicker: allowDomainPicker,
-		voiceEnabled:      voiceEnabled,
+		allowDomainPicker: opts.AllowDomainPicker,
+		voiceBackend:      opts.Voice,
+		handsFree:         opts.HandsFree,
 		history:           []exchange{},
 		textarea:          ta,
 		viewport:          vp,
 		spinner:           sp,
 		totalSessions:     totalSessions,
-		currentStreak:     currentStreak,
+		streak:            streakStatus,
+		weekCount:         weekCount,
+		weeklyGoal:        opts.WeeklyGoal,
+		plan:              plan,
 		dueCount:          dueCount,
 		dueWeekCount:      dueWeekCount,
 		newSkillCount:     newSkillCount,
@@ -236,6 +304,7 @@ func NewModel(database *db.DB, skill *skills.Skill, allowDomainPicker bool, voic
 		recentRatings:     recentRatings,
 		recentSessions:    recentSessions,
 		weakFacets:        weakFacets,
+		deliveryTrend:     deliveryTrend,
 		selectedDomain:    defaultDomain,
 	}
 }
@@ -247,37 +316,162 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) createSession() tea.Cmd {
 	return func() tea.Msg {
-		id, err := m.db.CreateSession(m.skill.ID)
+		id, err := m.db.CreateSession(m.skill.ID, m.conversation.PromptVersion())
 		return sessionCreatedMsg{sessionID: id, err: err}
 	}
 }
 
-func (m Model) getCoachResponse(userMsg string) tea.Cmd {
+// getCoachResponse sends userMsg (empty to start the drill) in the
+// background. Only one request is in flight at a time; esc cancels it.
+func (m *Model) getCoachResponse(userMsg string) tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelRequest = cancel
 	conv := m.conversation
 	return func() tea.Msg {
-		resp, err := conv.Send(userMsg)
+		defer cancel()
+		resp, err := conv.Send(ctx, userMsg)
 		return coachResponseMsg{resp: resp, err: err}
 	}
 }
 
+// retryRequest resends the answer or help request that failed, or restarts
+// the drill if the opening question failed.
+func (m Model) retryRequest() (tea.Model, tea.Cmd) {
+	m.requestErr = nil
+	m.state = stateLoading
+	if m.pendingHelp != "" {
+		return m, m.askForHelp(m.pendingHelp)
+	}
+	return m, m.getCoachResponse(m.pendingAnswer)
+}
+
+// recordUsage stores the tokens spent on a coach reply.
+func (m *Model) recordUsage(resp *llm.Response) {
+	u := db.UsageRecord{
+		SessionID: m.sessionID,
+		Turn:      m.turn,
+		Kind:      db.UsageDrill,
+		Model:     resp.Model,
+		Tokens:    DBTokens(resp.Usage),
+	}
+	if m.sessionID == "" {
+		m.unsavedUsage = append(m.unsavedUsage, u)
+		return
+	}
+	m.db.RecordUsage(u)
+}
+
+// questionBank returns the questions already asked for the skill, one line
+// each, and the problems they used.
+func (m Model) questionBank() ([]string, []string) {
+	asked, _ := m.db.GetAskedQuestions(m.skill.ID, 15)
+	lines := make([]string, 0, len(asked))
+	for _, q := range asked {
+		line := q.Summary
+		if q.Facet != "" {
+			line = "[" + q.Facet + "] " + line
+		}
+		var notes []string
+		if q.Problem != "" {
+			notes = append(notes, "problem: "+q.Problem)
+		}
+		if q.TimesAsked > 1 {
+			notes = append(notes, fmt.Sprintf("asked %d times", q.TimesAsked))
+		}
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+		lines = append(lines, line)
+	}
+	problems, _ := m.db.GetUsedProblems(m.skill.ID)
+	return lines, problems
+}
+
+// commitPendingAnswer records the answer the coach just replied to, with
+// bonk's check of its numbers in estimation drills and the help taken.
+func (m *Model) commitPendingAnswer(check *estimate.Result) {
+	if m.pendingAnswer == "" {
+		return
+	}
+	revealed := m.pendingHelp == helpReveal
+	if revealed {
+		m.help.Reveals++
+	}
+	if m.lastResp != nil {
+		checkText := ""
+		if check != nil {
+			checkText = check.Summary()
+		}
+		m.db.SaveExchange(m.sessionID, db.Exchange{
+			Turn:         m.turn,
+			Question:     m.lastResp.Text,
+			QuestionType: m.lastResp.QuestionType,
+			Facet:        m.lastResp.Facet,
+			Answer:       m.pendingAnswer,
+			Delivery:     toDBDelivery(m.pendingDelivery),
+			CoachRating:  m.lastResp.LLMRating,
+			Phase:        m.lastResp.Phase,
+			Check:        checkText,
+			Hints:        len(m.hints),
+			Revealed:     revealed,
+			Struggled:    revealed,
+		})
+		m.history = append(m.history, exchange{
+			question: m.lastResp.Text,
+			hints:    m.hints,
+			answer:   m.pendingAnswer,
+			check:    check,
+		})
+	}
+	m.pendingAnswer = ""
+	m.pendingHelp = ""
+	m.hints = nil
+	m.pendingDelivery = nil
+	m.turn++
+}
+
 func (m Model) startRecording() tea.Cmd {
+	stt := m.voiceBackend.STT
 	return func() tea.Msg {
-		rec, err := voice.StartRecording()
+		rec, err := stt.StartRecording()
 		return recordingStartedMsg{rec: rec, err: err}
 	}
 }
 
 func (m Model) stopAndTranscribe() tea.Cmd {
 	rec := m.recordingProc
+	stt := m.voiceBackend.STT
 	return func() tea.Msg {
 		audioPath, err := rec.Stop()
 		if err != nil {
 			return transcriptionMsg{err: err}
 		}
-		text, err := voice.Transcribe(audioPath)
-		os.Remove(audioPath) // cleanup temp file
-		return transcriptionMsg{text: text, err: err}
+		return transcribe(stt, audioPath, false)()
+	}
+}
+
+// submitAnswer saves the current exchange and sends the answer to the coach.
+func (m Model) submitAnswer() (tea.Model, tea.Cmd) {
+	answer := strings.TrimSpace(m.textarea.Value())
+	if answer == "" {
+		return m, nil
 	}
+	// Stop any ongoing speech
+	if m.speechProc != nil {
+		m.speechProc.Stop()
+		m.speechProc = nil
+	}
+	m.cancelAutoSubmit()
+	m.voiceNotice = ""
+
+	// The exchange is saved once the coach replies, so a failed request can
+	// be retried or edited without leaving a duplicate behind.
+	m.pendingAnswer = answer
+	m.textarea.Reset()
+	m.state = stateLoading
+	m.shareNotebook()
+
+	return m, m.getCoachResponse(answer)
 }
 
 func (m *Model) startDrill() tea.Cmd {
@@ -289,7 +483,10 @@ func (m *Model) startDrill() tea.Cmd {
 
 	// Set maxTurns based on domain - practical interviews need more exchanges
 	if m.skill.Domain == "system-design-practical" {
-		m.maxTurns = 40 // Full interview simulation with 6 phases
+		m.maxTurns = m.practicalMaxTurns // Full interview simulation with 6 phases
+		m.phases = pacing.NewTracker()
+		m.doc = notebook.New()
+		m.notePad = newNotePad()
 	}
 
 	// Initialize conversation
@@ -309,7 +506,15 @@ func (m *Model) startDrill() tea.Cmd {
 	m.historyCtx = historyCtx
 	m.difficulty = llm.DifficultyLevel(perf)
 
-	m.conversation = llm.NewConversation(m.skill, historyCtx, perf, m.maxTurns)
+	history := llm.History{Recent: historyCtx}
+	history.Asked, history.Problems = m.questionBank()
+	conv, err := llm.NewConversation(m.skill, history, perf, m.maxTurns, nil)
+	if err != nil {
+		// Most likely a broken template override
+		m.err = err
+		return tea.Quit
+	}
+	m.conversation = conv
 	m.systemPrompt = m.conversation.SystemPrompt()
 	m.state = stateLoading
 	m.textarea.Focus()
@@ -353,6 +558,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case "5":
 					m.selectedDomain = "leetcode-patterns"
 					return m, nil
+				case "6":
+					m.selectedDomain = "estimation"
+					return m, nil
 				}
 			}
 
@@ -374,10 +582,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.syncLayout()
 				return m, nil
 			}
+			var handled bool
+			var cmd tea.Cmd
+			if m, cmd, handled = m.handleBookmarkKey(msg); handled {
+				return m, cmd
+			}
+			if m, cmd, handled = m.handleNotebookKey(msg); handled {
+				return m, cmd
+			}
+			if m, cmd, handled = m.handleHandsFreeKey(msg); handled {
+				return m, cmd
+			}
+			if m, cmd, handled = m.handleHelpKey(msg); handled {
+				return m, cmd
+			}
 			switch msg.Type {
 			case tea.KeyCtrlC:
 				// Clear buffer
 				m.textarea.Reset()
+				m.pendingDelivery = nil
 				return m, nil
 			case tea.KeyEsc:
 				m.quitting = true
@@ -389,39 +612,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.textarea, cmd = m.textarea.Update(msg)
 					return m, cmd
 				}
-				// Submit answer
-				answer := strings.TrimSpace(m.textarea.Value())
-				if answer == "" {
-					return m, nil
-				}
-				// Stop any ongoing speech
-				if m.speechProc != nil {
-					m.speechProc.Stop()
-					m.speechProc = nil
-				}
-
-				// Save exchange
-				if m.lastResp != nil {
-					m.db.SaveExchange(
-						m.sessionID,
-						m.turn,
-						m.lastResp.Text,
-						m.lastResp.QuestionType,
-						m.lastResp.Facet,
-						answer,
-						false,
-					)
-				}
-
-				m.history = append(m.history, exchange{
-					question: m.lastResp.Text,
-					answer:   answer,
-				})
-				m.textarea.Reset()
-				m.state = stateLoading
-				m.turn++
-
-				return m, m.getCoachResponse(answer)
+				return m.submitAnswer()
 			default:
 				// q quits if buffer is empty
 				if msg.String() == "q" && strings.TrimSpace(m.textarea.Value()) == "" {
@@ -429,13 +620,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, tea.Quit
 				}
 				// s skips speech in voice mode
-				if msg.String() == "s" && m.voiceEnabled && m.speechProc != nil {
+				if msg.String() == "s" && m.speechProc != nil {
 					m.speechProc.Stop()
 					m.speechProc = nil
 					return m, nil
 				}
 				// space toggles recording in voice mode (when textarea empty)
-				if msg.String() == " " && m.voiceEnabled && strings.TrimSpace(m.textarea.Value()) == "" {
+				if msg.String() == " " && m.voiceBackend.CanRecord() && strings.TrimSpace(m.textarea.Value()) == "" {
 					if m.recording {
 						m.recording = false
 						m.transcribing = true
@@ -459,24 +650,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.syncLayout()
 				return m, nil
 			}
+			if m, cmd, handled := m.handleUnlockedKey(msg); handled {
+				return m, cmd
+			}
+			if m, cmd, handled := m.handleBookmarkKey(msg); handled {
+				return m, cmd
+			}
 			switch msg.String() {
 			case "1", "2", "3", "4":
 				userRating := int(msg.String()[0] - '0')
 				assessment := ""
 				if m.lastResp != nil {
 					assessment = m.lastResp.Assessment
+					// The closing assessment has no answer to wait for. A drill
+					// cut off at the turn limit ends on a question instead,
+					// saved unanswered.
+					m.db.SaveExchange(m.sessionID, db.Exchange{
+						Turn:         m.turn,
+						Question:     m.lastResp.Text,
+						QuestionType: m.lastResp.QuestionType,
+						Facet:        m.lastResp.Facet,
+						CoachRating:  m.lastResp.LLMRating,
+						Phase:        m.lastResp.Phase,
+						Final:        m.lastResp.IsFinal,
+					})
 				}
-				// Compute combined rating (average of user + LLM, rounded)
-				finalRating := userRating
-				if m.llmRating > 0 {
-					finalRating = (userRating + m.llmRating + 1) / 2 // +1 for rounding
+				if m.phases != nil {
+					m.db.SavePhaseStats(m.sessionID, m.phaseStats())
 				}
-				m.db.FinishSession(m.sessionID, finalRating, assessment)
+				m.saveNotebook()
+				m.db.FinishSession(m.clock, m.sessionID, db.Ratings{
+					Self:  userRating,
+					Coach: m.llmRating,
+					Final: m.help.Cap(m.ratingBlend.Combine(userRating, m.llmRating)),
+					Help:  m.help,
+				}, assessment)
 				m.continueToNext = true
+				if m.unlockAchievements() {
+					return m, nil
+				}
 				return m, tea.Quit
 			case "c":
 				// Continue exploring - go back to drilling state
 				m.state = stateDrilling
+				m.notebookFocused = false
 				m.textarea.Focus()
 				return m, nil
 			case "q", "esc":
@@ -494,7 +711,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.syncLayout()
 				return m, nil
 			}
-			if msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC || msg.String() == "q" {
+			if msg.Type == tea.KeyEsc {
+				// The request returns context.Canceled and lands in stateError.
+				if m.cancelRequest != nil {
+					m.cancelRequest()
+				}
+				return m, nil
+			}
+			if msg.Type == tea.KeyCtrlC || msg.String() == "q" {
+				if m.cancelRequest != nil {
+					m.cancelRequest()
+				}
+				m.quitting = true
+				return m, tea.Quit
+			}
+
+		case stateError:
+			switch {
+			case msg.String() == "r" || msg.Type == tea.KeyEnter:
+				return m.retryRequest()
+			case (msg.String() == "e" || msg.Type == tea.KeyEsc) && (m.pendingAnswer != "" || m.pendingHelp != ""):
+				// Back to the answer box with the answer restored.
+				if m.pendingHelp == "" {
+					m.textarea.SetValue(m.pendingAnswer)
+				}
+				m.pendingAnswer = ""
+				m.pendingHelp = ""
+				m.requestErr = nil
+				m.state = stateDrilling
+				m.textarea.Focus()
+				return m, nil
+			case msg.String() == "q" || msg.Type == tea.KeyEsc || msg.Type == tea.KeyCtrlC:
 				m.quitting = true
 				return m, tea.Quit
 			}
@@ -511,48 +758,94 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 		m.sessionID = msg.sessionID
+		// The opening question can arrive before the session row exists.
+		for _, u := range m.unsavedUsage {
+			u.SessionID = m.sessionID
+			m.db.RecordUsage(u)
+		}
+		m.unsavedUsage = nil
+		m.saveNotebook()
 
 	case coachResponseMsg:
+		m.cancelRequest = nil
 		if msg.err != nil {
-			m.err = msg.err
-			return m, tea.Quit
+			m.requestErr = msg.err
+			m.state = stateError
+			return m, nil
+		}
+		if msg.resp.Hint > 0 {
+			cmds = append(cmds, m.showHint(msg.resp))
+			break
 		}
+		m.commitPendingAnswer(msg.resp.Check)
 		m.lastResp = msg.resp
+		m.bookmarked = false
 		m.turn++
+		m.recordUsage(msg.resp)
 
 		// Update phase for system-design-practical
 		if msg.resp.Phase != "" {
 			m.phase = msg.resp.Phase
 		}
+		if m.phases != nil {
+			m.phases.Observe(msg.resp.Phase, time.Now())
+		}
 
 		if msg.resp.IsFinal || m.turn > m.maxTurns {
+			if m.phases != nil {
+				m.phases.Finish(time.Now())
+			}
 			m.state = stateRating
 			m.llmRating = msg.resp.LLMRating
 		} else {
 			m.state = stateDrilling
+			m.db.RecordQuestion(m.skill.ID, db.Question{
+				Facet:   msg.resp.Facet,
+				Problem: msg.resp.Problem,
+				Text:    msg.resp.Text,
+			})
 			// Speak coach question if voice mode enabled
-			if m.voiceEnabled {
-				m.speechProc = voice.Speak(msg.resp.Text)
+			if m.voiceBackend.CanSpeak() {
+				m.speechProc = m.voiceBackend.TTS.Speak(msg.resp.Text)
 			}
+			cmds = append(cmds, m.awaitSpeechEnd())
 		}
 
 	case recordingStartedMsg:
 		if msg.err != nil {
 			// Recording failed to start - stay in drilling state
-			// TODO: show error to user
+			m.voiceNotice = msg.err.Error()
 			return m, nil
 		}
 		m.recording = true
 		m.recordingProc = msg.rec
+		m.voiceNotice = ""
 
 	case transcriptionMsg:
 		m.recording = false
 		m.transcribing = false
 		m.recordingProc = nil
-		if msg.err == nil && msg.text != "" {
+		if msg.err != nil {
+			m.voiceNotice = msg.err.Error()
+		} else if msg.text != "" {
 			m.textarea.SetValue(msg.text)
+			m.pendingDelivery = msg.delivery
+			if msg.auto && m.state == stateDrilling {
+				cmds = append(cmds, m.startAutoSubmit())
+			}
 		}
-		// TODO: show error if transcription failed
+
+	case speechDoneMsg:
+		return m.handleSpeechDone(msg)
+
+	case listenStartedMsg:
+		return m.handleListenStarted(msg)
+
+	case listenDoneMsg:
+		return m.handleListenDone(msg)
+
+	case autoSubmitTickMsg:
+		return m.handleAutoSubmitTick(msg)
 
 	case spinner.TickMsg:
 		if m.state == stateLoading {
@@ -633,53 +926,88 @@ func (m Model) renderMainContent() string {
 
 	switch m.state {
 	case stateLoading:
-		for _, ex := range m.history {
-			b.WriteString(coachLabelStyle.Render("Coach") + "\n")
-			b.WriteString(renderMarkdown(ex.question, mainWidth-4) + "\n")
-			b.WriteString(userLabelStyle.Render("You") + "\n")
-			b.WriteString(userStyle.Render(wordWrap(ex.answer, mainWidth-4)) + "\n\n")
-		}
+		b.WriteString(m.renderTranscript(mainWidth))
 		b.WriteString("\n")
 		b.WriteString(m.spinner.View() + " " + loadingStyle.Render("Thinking..."))
+		b.WriteString("\n\n" + helpStyle.Render("esc cancel • q quit"))
+
+	case stateError:
+		b.WriteString(m.renderTranscript(mainWidth))
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ "+requestErrorText(m.requestErr)) + "\n\n")
+		help := "r retry • q quit"
+		if m.pendingHelp != "" {
+			help = "r retry • e back • q quit"
+		} else if m.pendingAnswer != "" {
+			help = "r retry • e edit answer • q quit"
+		}
+		b.WriteString(helpStyle.Render(help))
 
 	case stateDrilling:
 		for _, ex := range m.history {
 			b.WriteString(coachLabelStyle.Render("Coach") + "\n")
 			b.WriteString(renderMarkdown(ex.question, mainWidth-4) + "\n")
+			b.WriteString(renderHints(ex.hints, mainWidth-4))
 			b.WriteString(userLabelStyle.Render("You") + "\n")
-			b.WriteString(userStyle.Render(wordWrap(ex.answer, mainWidth-4)) + "\n\n")
+			b.WriteString(userStyle.Render(wordWrap(ex.answer, mainWidth-4)) + "\n")
+			b.WriteString(renderCheck(ex.check, mainWidth-4) + "\n")
 		}
 
 		if m.lastResp != nil {
 			b.WriteString(coachLabelStyle.Render("Coach") + "\n")
 			b.WriteString(renderMarkdown(m.lastResp.Text, mainWidth-4) + "\n")
+			b.WriteString(renderHints(m.hints, mainWidth-4))
 		}
 
 		b.WriteString(userLabelStyle.Render("You"))
 		if m.recording {
 			recStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
 			b.WriteString("  " + recStyle.Render("● REC"))
+		} else if m.listening {
+			listenStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("114")).Bold(true)
+			b.WriteString("  " + listenStyle.Render("◌ listening..."))
 		} else if m.transcribing {
 			transcribeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
 			b.WriteString("  " + transcribeStyle.Render("transcribing..."))
+		} else if m.autoSubmitIn > 0 {
+			sendStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
+			b.WriteString("  " + sendStyle.Render(fmt.Sprintf("sending in %ds", m.autoSubmitIn)))
+		} else if m.voiceNotice != "" {
+			b.WriteString("  " + helpStyle.Render(m.voiceNotice))
 		}
 		b.WriteString("\n")
 		b.WriteString(m.textarea.View() + "\n\n")
 		var help string
 		if m.recording {
 			help = "space stop recording • esc quit"
+		} else if m.listening {
+			help = "space done • esc stop listening • type to answer"
 		} else if m.transcribing {
 			help = "transcribing audio..."
-		} else if m.voiceEnabled {
-			if m.speechProc != nil {
+		} else if m.autoSubmitIn > 0 {
+			help = "enter send now • esc cancel • any key edit"
+		} else if m.speechProc != nil {
+			help = "s skip • enter submit • esc quit"
+			if m.voiceBackend.CanRecord() {
 				help = "s skip • space record • enter submit • esc quit"
-			} else {
-				help = "space record • enter submit • ctrl+c clear • esc quit • tab sidebar"
 			}
+		} else if m.voiceBackend.CanRecord() {
+			help = "space record • enter submit • ctrl+t hint • ctrl+r reveal • esc quit • tab sidebar"
 		} else {
-			help = "enter submit • ctrl+c clear • esc quit • tab sidebar"
+			help = "enter submit • ctrl+t hint • ctrl+r reveal • ctrl+c clear • esc quit • tab sidebar"
+		}
+		if m.notebookFocused {
+			help = "editing notebook • esc back to answer"
+		} else if m.doc != nil && !m.recording && !m.transcribing {
+			help += " • ctrl+o notebook"
+		}
+		if !m.recording && !m.transcribing {
+			help += " • ctrl+s bookmark"
+		}
+		b.WriteString(m.renderBookmark())
+		if !m.noting {
+			b.WriteString(helpStyle.Render(help))
 		}
-		b.WriteString(helpStyle.Render(help))
 
 	case stateRating:
 		if m.lastResp != nil {
@@ -697,22 +1025,160 @@ func (m Model) renderMainContent() string {
 			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("Coach thinks: ") + llmLabel + "\n\n")
 		}
 
-		b.WriteString(ratingStyle.Render("How did that go?") + "\n\n")
-		b.WriteString("  " + ratingKeyStyle.Render("[1]") + ratingOptionStyle.Render(" Again  "))
-		b.WriteString(ratingKeyStyle.Render("[2]") + ratingOptionStyle.Render(" Hard  "))
-		b.WriteString(ratingKeyStyle.Render("[3]") + ratingOptionStyle.Render(" Good  "))
-		b.WriteString(ratingKeyStyle.Render("[4]") + ratingOptionStyle.Render(" Easy") + "\n\n")
-		help := "1-4 rate • c continue • q quit • tab sidebar"
-		b.WriteString(helpStyle.Render(help))
+		if m.phases != nil {
+			b.WriteString(renderPacing(m.phaseStats()))
+		}
+		if est := m.conversation.Estimate(); est != nil && len(est.Results) > 0 {
+			b.WriteString(renderEstimateScore(est, mainWidth-4))
+		}
+		if note := helpNote(m.help); note != "" {
+			b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(note) + "\n\n")
+		}
+
+		if len(m.unlocked) > 0 {
+			b.WriteString(renderUnlocked(m.unlocked))
+			b.WriteString(helpStyle.Render("any key next drill • q quit"))
+		} else {
+			b.WriteString(ratingStyle.Render("How did that go?") + "\n\n")
+			b.WriteString("  " + ratingKeyStyle.Render("[1]") + ratingOptionStyle.Render(" Again  "))
+			b.WriteString(ratingKeyStyle.Render("[2]") + ratingOptionStyle.Render(" Hard  "))
+			b.WriteString(ratingKeyStyle.Render("[3]") + ratingOptionStyle.Render(" Good  "))
+			b.WriteString(ratingKeyStyle.Render("[4]") + ratingOptionStyle.Render(" Easy") + "\n\n")
+			b.WriteString(m.renderBookmark())
+			if !m.noting {
+				help := "1-4 rate • c continue • ctrl+s bookmark • q quit • tab sidebar"
+				b.WriteString(helpStyle.Render(help))
+			}
+		}
 	}
 
 	return b.String()
 }
 
+// phaseStats is the interview's pacing with the coach's phase scores.
+func (m Model) phaseStats() []db.PhaseStat {
+	var scores map[string]int
+	if m.lastResp != nil {
+		scores = m.lastResp.PhaseScores
+	}
+	var stats []db.PhaseStat
+	for _, s := range m.phases.Stats(time.Now()) {
+		stats = append(stats, db.PhaseStat{
+			Phase:   s.Phase,
+			Turns:   s.Turns,
+			Seconds: s.Duration.Seconds(),
+			Score:   scores[s.Phase],
+		})
+	}
+	return stats
+}
+
+// renderPacing shows time, turns and score per phase with pacing warnings.
+func renderPacing(stats []db.PhaseStat) string {
+	if len(stats) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	var pstats []pacing.Stat
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("Pacing:") + "\n")
+	for _, s := range stats {
+		d := time.Duration(s.Seconds * float64(time.Second))
+		pstats = append(pstats, pacing.Stat{Phase: s.Phase, Turns: s.Turns, Duration: d})
+		score := "  "
+		if s.Score > 0 {
+			score = ratingGlyph(s.Score) + " "
+		}
+		b.WriteString(fmt.Sprintf("  %s%-18s %10s  %2d turns\n", score, pacing.Title(s.Phase), pacing.FormatDuration(d), s.Turns))
+	}
+	for _, w := range pacing.Warnings(pstats) {
+		b.WriteString(errorStyle.Render("  ! "+w) + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderCheck shows bonk's check of an estimate under the answer; empty
+// when there is none.
+func renderCheck(r *estimate.Result, width int) string {
+	if r == nil {
+		return ""
+	}
+	glyph, style := "✓", lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	switch {
+	case r.Verdict == estimate.Ballpark:
+		glyph, style = "~", lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	case !r.Passed():
+		glyph, style = "✗", errorStyle
+	}
+	return style.Render(wordWrap("  "+glyph+" "+r.Summary(), width)) + "\n"
+}
+
+// renderEstimateScore summarizes the checked numbers on the rating screen.
+func renderEstimateScore(est *estimate.Drill, width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(
+		fmt.Sprintf("Numbers: %d/4", est.Score())) + "\n")
+	for i := range est.Results {
+		b.WriteString(renderCheck(&est.Results[i], width))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderTranscript shows the finished exchanges plus an answer that is
+// still waiting on the coach.
+func (m Model) renderTranscript(width int) string {
+	var b strings.Builder
+	for _, ex := range m.history {
+		b.WriteString(coachLabelStyle.Render("Coach") + "\n")
+		b.WriteString(renderMarkdown(ex.question, width-4) + "\n")
+		b.WriteString(renderHints(ex.hints, width-4))
+		b.WriteString(userLabelStyle.Render("You") + "\n")
+		b.WriteString(userStyle.Render(wordWrap(ex.answer, width-4)) + "\n")
+		b.WriteString(renderCheck(ex.check, width-4) + "\n")
+	}
+	if (m.pendingAnswer != "" || m.pendingHelp != "") && m.lastResp != nil {
+		b.WriteString(coachLabelStyle.Render("Coach") + "\n")
+		b.WriteString(renderMarkdown(m.lastResp.Text, width-4) + "\n")
+		b.WriteString(renderHints(m.hints, width-4))
+		if m.pendingAnswer != "" {
+			b.WriteString(userLabelStyle.Render("You") + "\n")
+			b.WriteString(userStyle.Render(wordWrap(m.pendingAnswer, width-4)) + "\n\n")
+		}
+	}
+	return b.String()
+}
+
+// requestErrorText turns a failed coach request into a one-line message.
+func requestErrorText(err error) string {
+	var apiErr *llm.APIError
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "Request cancelled."
+	case errors.Is(err, context.DeadlineExceeded):
+		return "The coach took too long to answer."
+	case errors.As(err, &apiErr) && apiErr.Status == 529:
+		return "The API is overloaded right now."
+	case errors.As(err, &apiErr) && apiErr.Status == 429:
+		return "Rate limited by the API."
+	}
+	msg := err.Error()
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		msg = msg[:i]
+	}
+	if len(msg) > 200 {
+		msg = msg[:200] + "…"
+	}
+	return msg
+}
+
 func (m Model) renderSidebar() string {
 	if m.showDebug {
 		return m.renderDebugSidebar()
 	}
+	if m.notebookOpen {
+		return m.renderNotebook()
+	}
 
 	var b strings.Builder
 
@@ -726,10 +1192,13 @@ func (m Model) renderSidebar() string {
 	b.WriteString(labelStyle.Render("today") + "\n")
 	b.WriteString(valueStyle.Render(fmt.Sprintf("%d", m.todayCount)) + "\n\n")
 
-	if m.currentStreak > 0 {
+	if m.streak.Current > 0 {
 		b.WriteString(labelStyle.Render("streak") + "\n")
-		streakStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true)
-		b.WriteString(streakStyle.Render(fmt.Sprintf("%dd", m.currentStreak)) + "\n\n")
+		b.WriteString(streakStyle.Render(fmt.Sprintf("%dd", m.streak.Current)) + renderFreezes(m.streak.Freezes) + "\n\n")
+	}
+	if m.weeklyGoal > 0 {
+		b.WriteString(labelStyle.Render("week") + "\n")
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%d/%d", m.weekCount, m.weeklyGoal)) + "\n\n")
 	}
 
 	// Sparkline of recent ratings
@@ -762,7 +1231,20 @@ func (m Model) renderDebugSidebar() string {
 	}
 	b.WriteString(valueStyle.Render(wordWrap(history, m.sidebarWidth()-4)) + "\n\n")
 
-	b.WriteString(labelStyle.Render("system prompt") + "\n")
+	if r := m.lastResp; r != nil && (r.Reprompts > 0 || len(r.MetaIssues) > 0) {
+		b.WriteString(labelStyle.Render("metadata") + "\n")
+		meta := fmt.Sprintf("%d re-prompt(s)", r.Reprompts)
+		if len(r.MetaIssues) > 0 {
+			meta += "; still: " + strings.Join(r.MetaIssues, "; ")
+		}
+		b.WriteString(valueStyle.Render(wordWrap(meta, m.sidebarWidth()-4)) + "\n\n")
+	}
+
+	label := "system prompt"
+	if m.conversation != nil {
+		label += " " + m.conversation.PromptVersion()
+	}
+	b.WriteString(labelStyle.Render(label) + "\n")
 	promptPreview := m.systemPrompt
 	if len(promptPreview) > 700 {
 		promptPreview = promptPreview[:700] + "\n... (truncated)"
@@ -776,6 +1258,9 @@ func (m Model) sidebarWidth() int {
 	if m.showDebug {
 		return 52
 	}
+	if m.notebookOpen {
+		return notebookWidth
+	}
 	return 16
 }
 
@@ -865,10 +1350,19 @@ func (m Model) renderWelcome() string {
 
 	// Stats box
 	statsStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("252"))
-	b.WriteString(statsStyle.Render(fmt.Sprintf("  sessions: %-5d avg: %-4s streak: %d days", m.totalSessions, formatRating(m.avgRating), m.currentStreak)))
+	b.WriteString(statsStyle.Render(fmt.Sprintf("  sessions: %-5d avg: %-4s streak: %d days", m.totalSessions, formatRating(m.avgRating), m.streak.Current)))
+	b.WriteString(renderFreezes(m.streak.Freezes))
 	b.WriteString("\n")
 	b.WriteString(statsStyle.Render(fmt.Sprintf("  due now: %-5d due week: %-5d new: %d", m.dueCount, m.dueWeekCount, m.newSkillCount)))
 	b.WriteString("\n")
+	if line := m.renderGoals(); line != "" {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if line := m.renderPlan(); line != "" {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
 	if len(m.recentRatings) > 0 {
 		b.WriteString(statsStyle.Render("  recent: "))
 		b.WriteString(renderSparkline(m.recentRatings))
@@ -896,6 +1390,22 @@ func (m Model) renderWelcome() string {
 		}
 		b.WriteString("\n")
 	}
+	if line := renderDeliveryTrend(m.deliveryTrend); line != "" {
+		b.WriteString(helpStyle.Render("  delivery: "))
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if m.voiceBackend != nil {
+		status := "  voice: " + m.voiceBackend.Summary()
+		if m.handsFreeEnabled() {
+			status += " (hands-free)"
+		}
+		if m.voiceBackend.Reason != "" {
+			status += " (" + m.voiceBackend.Reason + ")"
+		}
+		b.WriteString(helpStyle.Render(status))
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
 
 	if m.domainPickerEnabled() {
@@ -912,6 +1422,7 @@ func (m Model) renderWelcome() string {
 			{"3", "system-design", "System Design", "sys"},
 			{"4", "system-design-practical", "System Design Practical", "sysp"},
 			{"5", "leetcode-patterns", "LeetCode Patterns", "lc"},
+			{"6", "estimation", "Estimation", "est"},
 		}
 		for _, opt := range options {
 			prefix := "  "
@@ -965,11 +1476,9 @@ func (m Model) renderHeader() string {
 
 	// For system-design-practical, show phase instead of turn
 	if m.skill.Domain == "system-design-practical" && m.phase != "" {
-		phaseName := phaseNames[m.phase]
-		phaseNum := phaseOrder[m.phase]
-		if phaseName != "" && phaseNum > 0 {
+		if p, n, ok := pacing.Lookup(m.phase); ok {
 			phaseStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
-			header += "  " + phaseStyle.Render(fmt.Sprintf("%s (%d/6)", phaseName, phaseNum))
+			header += "  " + phaseStyle.Render(fmt.Sprintf("%s (%d/%d)", p.Title, n, len(pacing.Phases)))
 		}
 	} else if m.turn > 0 {
 		// Standard turn indicator for other domains
@@ -991,6 +1500,8 @@ func domainShort(domain string) string {
 		return "sysp"
 	case "leetcode-patterns":
 		return "lc"
+	case "estimation":
+		return "est"
 	default:
 		return ""
 	}
@@ -1101,6 +1612,9 @@ func (m *Model) syncLayout() {
 	m.textarea.SetWidth(max(20, contentWidth-2))
 	m.viewport.Width = max(20, contentWidth)
 	m.viewport.Height = max(5, m.height-15)
+	if m.doc != nil {
+		m.notePad.SetHeight(max(6, m.height-16))
+	}
 }
 
 func cycleDomainSelection(current string, delta int) string {
@@ -1110,6 +1624,7 @@ func cycleDomainSelection(current string, delta int) string {
 		"system-design",
 		"system-design-practical",
 		"leetcode-patterns",
+		"estimation",
 	}
 
 	idx := 0
@@ -1183,3 +1698,59 @@ func relativeTime(sqliteDateTime string) string {
 	}
 	return fmt.Sprintf("%dd ago", days)
 }
+
+func toDBDelivery(d *voice.Delivery) *db.Delivery {
+	if d == nil || d.Words == 0 {
+		return nil
+	}
+	return &db.Delivery{
+		SpeechSeconds:  d.SpeechSeconds,
+		WordsPerMinute: d.WordsPerMinute,
+		Fillers:        d.Fillers,
+		Hedges:         d.Hedges,
+		PauseRatio:     d.PauseRatio,
+	}
+}
+
+// renderDeliveryTrend summarizes the latest voice session and marks each
+// metric with an arrow against the average of earlier sessions.
+func renderDeliveryTrend(trend []db.DeliverySummary) string {
+	if len(trend) == 0 {
+		return ""
+	}
+	last := trend[len(trend)-1]
+	var prior db.DeliverySummary
+	earlier := trend[:len(trend)-1]
+	for _, d := range earlier {
+		prior.WordsPerMinute += d.WordsPerMinute / float64(len(earlier))
+		prior.FillersPerAnswer += d.FillersPerAnswer / float64(len(earlier))
+		prior.HedgesPerAnswer += d.HedgesPerAnswer / float64(len(earlier))
+	}
+
+	// goodDir: -1 when lower is better, 0 when neither (speaking pace)
+	arrow := func(cur, prev float64, goodDir int) string {
+		if len(earlier) == 0 || prev == 0 || cur == prev {
+			return ""
+		}
+		glyph := "↓"
+		if cur > prev {
+			glyph = "↑"
+		}
+		if goodDir == 0 {
+			return helpStyle.Render(glyph)
+		}
+		return trendStyle(cur < prev).Render(glyph)
+	}
+
+	return fmt.Sprintf("%.0f wpm%s  %.1f fillers%s  %.1f hedges%s /answer",
+		last.WordsPerMinute, arrow(last.WordsPerMinute, prior.WordsPerMinute, 0),
+		last.FillersPerAnswer, arrow(last.FillersPerAnswer, prior.FillersPerAnswer, -1),
+		last.HedgesPerAnswer, arrow(last.HedgesPerAnswer, prior.HedgesPerAnswer, -1))
+}
+
+func trendStyle(better bool) lipgloss.Style {
+	if better {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("210"))
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"bonk/internal/db"
+	"bonk/internal/llm"
+)
+
+// The db and llm packages each keep their own token counts so neither has
+// to import the other; these convert between the two.
+
+// DBTokens converts the usage the API reported for a call for storage.
+func DBTokens(u llm.Usage) db.TokenUsage {
+	return db.TokenUsage{
+		Input:      u.InputTokens,
+		Output:     u.OutputTokens,
+		CacheWrite: u.CacheWriteTokens,
+		CacheRead:  u.CacheReadTokens,
+	}
+}
+
+// LLMUsage converts stored token counts back for pricing.
+func LLMUsage(u db.TokenUsage) llm.Usage {
+	return llm.Usage{
+		InputTokens:      u.Input,
+		OutputTokens:     u.Output,
+		CacheWriteTokens: u.CacheWrite,
+		CacheReadTokens:  u.CacheRead,
+	}
+}
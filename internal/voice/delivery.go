@@ -0,0 +1,131 @@
+package voice
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Delivery measures how a spoken answer was delivered.
+type Delivery struct {
+	Words          int
+	SpeechSeconds  float64 // from first to last voiced frame
+	WordsPerMinute float64
+	Fillers        int
+	Hedges         int
+	PauseRatio     float64 // share of SpeechSeconds spent silent
+}
+
+// Filler words and phrases that add no content.
+var fillerPhrases = []string{
+	"um", "uh", "erm", "basically", "kind of", "sort of", "you know",
+	"i mean", "actually", "literally", "like",
+}
+
+// Hedge phrases that undercut an answer's confidence.
+var hedgePhrases = []string{
+	"i think", "i guess", "i believe", "not sure", "maybe",
+	"probably", "perhaps", "possibly", "might be", "could be",
+}
+
+// Words before "like" that make it a verb or comparison rather than filler.
+var likeNonFiller = map[string]bool{
+	"would": true, "looks": true, "look": true, "looked": true, "seems": true,
+	"feel": true, "feels": true, "sounds": true, "something": true, "things": true,
+}
+
+var (
+	bracketTag = regexp.MustCompile(`\[[^\]]*\]|\([^)]*\)`)
+	wordChars  = regexp.MustCompile(`[a-z0-9']+`)
+)
+
+// AnalyzeDelivery combines the transcript with the recording's timing.
+// The audio is optional; without it only text metrics are filled in.
+func AnalyzeDelivery(transcript, audioPath string) *Delivery {
+	words := transcriptWords(transcript)
+	d := &Delivery{Words: len(words)}
+	d.Fillers, d.Hedges = countPhrases(words)
+
+	if audioPath == "" {
+		return d
+	}
+	audio, err := readWAV(audioPath)
+	if err != nil {
+		return d
+	}
+	d.SpeechSeconds, d.PauseRatio = speechTiming(audio)
+	if d.SpeechSeconds > 0 {
+		d.WordsPerMinute = float64(d.Words) / d.SpeechSeconds * 60
+	}
+	return d
+}
+
+// transcriptWords lowercases the transcript, drops whisper annotations like
+// [BLANK_AUDIO] or (coughs), and splits it into words.
+func transcriptWords(transcript string) []string {
+	clean := bracketTag.ReplaceAllString(strings.ToLower(transcript), " ")
+	clean = strings.ReplaceAll(clean, "’", "'")
+	return wordChars.FindAllString(clean, -1)
+}
+
+func countPhrases(words []string) (fillers, hedges int) {
+	for i := range words {
+		for _, p := range fillerPhrases {
+			if matchAt(words, i, p) {
+				if p == "like" && i > 0 && likeNonFiller[words[i-1]] {
+					continue
+				}
+				fillers++
+			}
+		}
+		for _, p := range hedgePhrases {
+			if matchAt(words, i, p) {
+				hedges++
+			}
+		}
+	}
+	return fillers, hedges
+}
+
+// matchAt reports whether the words starting at i spell phrase.
+func matchAt(words []string, i int, phrase string) bool {
+	parts := strings.Fields(phrase)
+	if i+len(parts) > len(words) {
+		return false
+	}
+	for j, p := range parts {
+		if words[i+j] != p {
+			return false
+		}
+	}
+	return true
+}
+
+// speechTiming returns the voiced span (leading/trailing silence trimmed)
+// and the fraction of that span that was silent.
+func speechTiming(audio *wavAudio) (seconds float64, pauseRatio float64) {
+	energies := frameEnergies(audio.samples, audio.sampleRate)
+	threshold := speechThreshold(energies)
+
+	first, last := -1, -1
+	for i, e := range energies {
+		if e >= threshold {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return 0, 0
+	}
+
+	span := energies[first : last+1]
+	silent := 0
+	for _, e := range span {
+		if e < threshold {
+			silent++
+		}
+	}
+	seconds = float64(len(span)*frameMillis) / 1000
+	return seconds, float64(silent) / float64(len(span))
+}
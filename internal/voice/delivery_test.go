@@ -0,0 +1,106 @@
+package voice
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountPhrases(t *testing.T) {
+	words := transcriptWords("Um, I think it's basically like a hash map [BLANK_AUDIO]. It looks like O(1), maybe.")
+	fillers, hedges := countPhrases(words)
+	// um, basically, like (not "looks like")
+	if fillers != 3 {
+		t.Errorf("fillers = %d, want 3", fillers)
+	}
+	// i think, maybe
+	if hedges != 2 {
+		t.Errorf("hedges = %d, want 2", hedges)
+	}
+}
+
+// writeTestWAV writes 16kHz mono audio: each segment is seconds of tone or silence.
+func writeTestWAV(t *testing.T, segments []struct {
+	seconds float64
+	loud    bool
+}) string {
+	t.Helper()
+	const rate = 16000
+	var samples []int16
+	for _, seg := range segments {
+		n := int(seg.seconds * rate)
+		for i := 0; i < n; i++ {
+			var v float64
+			if seg.loud {
+				v = 8000 * math.Sin(2*math.Pi*440*float64(i)/rate)
+			}
+			samples = append(samples, int16(v))
+		}
+	}
+
+	data := make([]byte, 44+2*len(samples))
+	copy(data[0:], "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(36+2*len(samples)))
+	copy(data[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(data[16:], 16)
+	binary.LittleEndian.PutUint16(data[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(data[22:], 1) // mono
+	binary.LittleEndian.PutUint32(data[24:], rate)
+	binary.LittleEndian.PutUint32(data[28:], rate*2)
+	binary.LittleEndian.PutUint16(data[32:], 2)
+	binary.LittleEndian.PutUint16(data[34:], 16)
+	copy(data[36:], "data")
+	binary.LittleEndian.PutUint32(data[40:], uint32(2*len(samples)))
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(data[44+2*i:], uint16(s))
+	}
+
+	path := filepath.Join(t.TempDir(), "answer.wav")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAnalyzeDeliveryTiming(t *testing.T) {
+	// 1s lead-in silence, 3s speech, 1s pause, 2s speech, 1s trailing silence
+	path := writeTestWAV(t, []struct {
+		seconds float64
+		loud    bool
+	}{{1, false}, {3, true}, {1, false}, {2, true}, {1, false}})
+
+	d := AnalyzeDelivery("one two three four five six seven eight nine ten twelve twelve", path)
+	if math.Abs(d.SpeechSeconds-6) > 0.1 {
+		t.Errorf("speech seconds = %.2f, want ~6", d.SpeechSeconds)
+	}
+	if math.Abs(d.PauseRatio-1.0/6) > 0.02 {
+		t.Errorf("pause ratio = %.3f, want ~0.167", d.PauseRatio)
+	}
+	if math.Abs(d.WordsPerMinute-120) > 3 {
+		t.Errorf("wpm = %.1f, want ~120", d.WordsPerMinute)
+	}
+}
+
+func TestAnalyzeDeliveryUnreadableAudio(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answer.wav")
+	header := make([]byte, 20)
+	copy(header[0:], "RIFF")
+	copy(header[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(header[16:], 0xFFFFFFF0) // corrupt fmt size
+	if err := os.WriteFile(path, header, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readWAV(path); err == nil {
+		t.Error("readWAV accepted a 4GB fmt chunk")
+	}
+
+	d := AnalyzeDelivery("um so I think we shard by user", path)
+	if d.SpeechSeconds != 0 || d.WordsPerMinute != 0 {
+		t.Errorf("untimed delivery = %+v", d)
+	}
+	if d.Fillers != 1 || d.Hedges != 1 {
+		t.Errorf("fillers, hedges = %d, %d; want 1, 1", d.Fillers, d.Hedges)
+	}
+}
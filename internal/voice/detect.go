@@ -0,0 +1,115 @@
+package voice
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Detect probes the machine for usable TTS and STT engines.
+//
+//	macOS: say for speech; sox + whisper.cpp for input
+//	Linux: piper (with a voice in ~/.bonk/piper) or espeak-ng for speech;
+//	       sox or arecord + whisper.cpp for input
+//
+// Missing pieces are described in Backend.Reason rather than returned as
+// errors so callers can fall back to text mode.
+func Detect(settings Settings) *Backend {
+	b := &Backend{}
+	var missing []string
+
+	if tts, hint := detectTTS(settings); tts != nil {
+		b.TTS = tts
+	} else {
+		missing = append(missing, hint)
+	}
+
+	if stt, hint := detectSTT(settings); stt != nil {
+		b.STT = stt
+	} else {
+		missing = append(missing, hint)
+	}
+
+	if len(missing) > 0 {
+		b.Reason = strings.Join(missing, "; ")
+	}
+	return b
+}
+
+func detectTTS(settings Settings) (TTS, string) {
+	switch runtime.GOOS {
+	case "darwin":
+		if hasBinary("say") {
+			return sayTTS{voice: settings.Voice, rate: settings.Rate}, ""
+		}
+		return nil, "no speech output ('say' not found)"
+	case "linux":
+		if hasBinary("piper") {
+			if model, player := piperModel(settings.DataDir, settings.Voice), rawPlayer(); model != "" && player != nil {
+				return piperTTS{model: model, player: player, rate: settings.Rate}, ""
+			}
+		}
+		// A piper model name isn't an espeak voice, so only pass plain names through.
+		espeakVoice := settings.Voice
+		if strings.HasSuffix(espeakVoice, ".onnx") {
+			espeakVoice = ""
+		}
+		for _, bin := range []string{"espeak-ng", "espeak"} {
+			if hasBinary(bin) {
+				return espeakTTS{bin: bin, voice: espeakVoice, rate: settings.Rate}, ""
+			}
+		}
+		return nil, "no speech output (install espeak-ng or piper)"
+	}
+	return nil, "no speech output on " + runtime.GOOS
+}
+
+func detectSTT(settings Settings) (STT, string) {
+	recorder := ""
+	switch {
+	case hasBinary("sox"):
+		recorder = "sox"
+	case runtime.GOOS == "linux" && hasBinary("arecord"):
+		recorder = "arecord"
+	default:
+		return nil, "no speech input (install sox" + linuxOnly(" or alsa-utils") + ")"
+	}
+
+	whisper := ""
+	for _, bin := range WhisperBinaries {
+		if hasBinary(bin) {
+			whisper = bin
+			break
+		}
+	}
+	if whisper == "" {
+		return nil, "no speech input (whisper.cpp not found, run 'bonk setup')"
+	}
+
+	if _, err := os.Stat(settings.ModelPath); err != nil {
+		return nil, "no speech input (whisper model " + settings.WhisperModel + " missing, run 'bonk setup')"
+	}
+	if m, ok := LookupWhisperModel(settings.WhisperModel); ok && m.EnglishOnly() && settings.Language != "en" && settings.Language != "" {
+		return nil, "no speech input (" + m.Name + " only supports English, choose a multilingual model)"
+	}
+
+	return whisperSTT{
+		recorder:  recorder,
+		whisper:   whisper,
+		modelPath: settings.ModelPath,
+		language:  settings.Language,
+	}, ""
+}
+
+func hasBinary(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func linuxOnly(s string) string {
+	if runtime.GOOS == "linux" {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package voice
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WhisperModel is a published whisper.cpp ggml model.
+type WhisperModel struct {
+	Name string
+	Size string
+	SHA1 string // from whisper.cpp's models/README.md
+}
+
+// WhisperModels are the models `bonk setup` knows how to download and verify.
+// Names without ".en" are multilingual.
+var WhisperModels = []WhisperModel{
+	{"tiny", "75 MB", "bd577a113a864445d4c299885e0cb97d4ba92b5f"},
+	{"tiny.en", "75 MB", "c78c86eb1a8faa21b369bcd33207cc90d64ae9df"},
+	{"base", "142 MB", "465707469ff3a37a2b9b8d8f89f2f99de7299dac"},
+	{"base.en", "142 MB", "137c40403d78fd54d454da0f9bd998f78703390c"},
+	{"small", "466 MB", "55356645c2b361a969dfd0ef2c5a50d530afd8d5"},
+	{"small.en", "466 MB", "db8a495a91d927739e50b3fc1cc4c6b8f6c2d022"},
+	{"medium", "1.5 GB", "fd9727b6e1217c2f614f9b698455c4ffd82463b4"},
+	{"medium.en", "1.5 GB", "8c30f0e44ce9560643ebd10bbe50cd20eafd3723"},
+	{"large-v3", "2.9 GB", "ad82bf6a9043ceed055076d0fd39f5f186ff8062"},
+	{"large-v3-turbo", "1.5 GB", "4af2b29d7ec73d781377bfd1758ca957a807e941"},
+}
+
+// LookupWhisperModel finds a known model by name.
+func LookupWhisperModel(name string) (WhisperModel, bool) {
+	for _, m := range WhisperModels {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return WhisperModel{}, false
+}
+
+// URL is where the model is downloaded from.
+func (m WhisperModel) URL() string {
+	return "https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-" + m.Name + ".bin"
+}
+
+// EnglishOnly reports whether the model only transcribes English.
+func (m WhisperModel) EnglishOnly() bool {
+	return strings.HasSuffix(m.Name, ".en")
+}
+
+// DefaultModelPath returns <dataDir>/ggml-<name>.bin.
+func DefaultModelPath(dataDir, name string) string {
+	return filepath.Join(dataDir, "ggml-"+name+".bin")
+}
+
+// VerifyChecksum checks a downloaded file against an expected SHA1.
+func VerifyChecksum(path, wantSHA1 string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha1.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	got := hex.EncodeToString(h.Sum(nil))
+	if got != wantSHA1 {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", got, wantSHA1)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package voice
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// whisperSTT records with sox or arecord and transcribes with whisper.cpp.
+type whisperSTT struct {
+	recorder  string // "sox" or "arecord"
+	whisper   string // whisper.cpp CLI binary
+	modelPath string
+	language  string
+}
+
+func (w whisperSTT) Name() string { return w.recorder + "/" + w.whisper }
+
+// StartRecording begins recording 16kHz mono audio.
+// Returns a Recording that can be stopped later.
+func (w whisperSTT) StartRecording() (*Recording, error) {
+	path := tempAudioPath()
+	var cmd *exec.Cmd
+	switch w.recorder {
+	case "arecord":
+		// -q: quiet, -f S16_LE: 16-bit, -r 16000: sample rate, -c 1: mono
+		cmd = exec.Command("arecord", "-q", "-f", "S16_LE", "-r", "16000", "-c", "1", path)
+	default:
+		// sox -d: default input device, -q: quiet, -r 16000: sample rate, -c 1: mono
+		cmd = exec.Command("sox", "-d", "-q", "-r", "16000", "-c", "1", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start recording: %w", err)
+	}
+	return &Recording{cmd: cmd, AudioPath: path}, nil
+}
+
+// Transcribe runs whisper.cpp on an audio file and returns the transcription.
+func (w whisperSTT) Transcribe(audioPath string) (string, error) {
+	if _, err := os.Stat(w.modelPath); os.IsNotExist(err) {
+		return "", fmt.Errorf("whisper model not found at %s - run: bonk setup", w.modelPath)
+	}
+	args := []string{"-m", w.modelPath, "-f", audioPath, "--no-timestamps"}
+	if w.language != "" {
+		args = append(args, "-l", w.language)
+	}
+	cmd := exec.Command(w.whisper, args...)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("whisper transcription failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// WhisperBinaries lists whisper.cpp CLI names in preference order. Newer
+// builds ship whisper-cli; some distro packages still use whisper-cpp.
+var WhisperBinaries = []string{"whisper-cli", "whisper-cpp"}
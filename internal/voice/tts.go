@@ -0,0 +1,114 @@
+package voice
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// sayTTS uses the macOS `say` command.
+type sayTTS struct {
+	voice string
+	rate  int
+}
+
+func (sayTTS) Name() string { return "say" }
+
+func (s sayTTS) Speak(text string) *SpeechProcess {
+	clean := StripMarkdown(text)
+	if clean == "" {
+		return nil
+	}
+	args := []string{"-r", strconv.Itoa(s.rate)}
+	if s.voice != "" {
+		args = append(args, "-v", s.voice)
+	}
+	cmd := exec.Command("say", append(args, clean)...)
+	return startSpeech(cmd)
+}
+
+// espeakTTS uses espeak-ng (or legacy espeak) on Linux.
+type espeakTTS struct {
+	bin   string
+	voice string
+	rate  int
+}
+
+func (e espeakTTS) Name() string { return e.bin }
+
+func (e espeakTTS) Speak(text string) *SpeechProcess {
+	clean := StripMarkdown(text)
+	if clean == "" {
+		return nil
+	}
+	args := []string{"-s", strconv.Itoa(e.rate)}
+	if e.voice != "" {
+		args = append(args, "-v", e.voice)
+	}
+	cmd := exec.Command(e.bin, append(args, clean)...)
+	return startSpeech(cmd)
+}
+
+// piperTTS pipes text through piper's neural TTS into a raw audio player.
+type piperTTS struct {
+	model  string
+	player []string
+	rate   int
+}
+
+func (p piperTTS) Name() string { return "piper" }
+
+func (p piperTTS) Speak(text string) *SpeechProcess {
+	clean := StripMarkdown(text)
+	if clean == "" {
+		return nil
+	}
+	// piper's default speaking speed is ~170 wpm; length_scale < 1 speeds it up.
+	lengthScale := strconv.FormatFloat(170.0/float64(p.rate), 'f', 2, 64)
+	pipeline := "piper --quiet --model " + shellQuote(p.model) +
+		" --length_scale " + lengthScale + " --output-raw | " + strings.Join(p.player, " ")
+	cmd := exec.Command("sh", "-c", pipeline)
+	cmd.Stdin = strings.NewReader(clean)
+	// Run in its own process group so Stop can kill the whole pipeline.
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return startSpeech(cmd)
+}
+
+// rawPlayer returns a command that plays 22.05kHz 16-bit mono PCM from stdin.
+func rawPlayer() []string {
+	if _, err := exec.LookPath("aplay"); err == nil {
+		return []string{"aplay", "-q", "-r", "22050", "-f", "S16_LE", "-t", "raw", "-"}
+	}
+	if _, err := exec.LookPath("paplay"); err == nil {
+		return []string{"paplay", "--raw", "--rate=22050", "--format=s16le", "--channels=1"}
+	}
+	return nil
+}
+
+// piperModel resolves the configured voice to a piper .onnx model: a path,
+// a name in <dataDir>/piper, or the first voice found there.
+func piperModel(dataDir, voice string) string {
+	dir := filepath.Join(dataDir, "piper")
+	if voice != "" {
+		for _, candidate := range []string{voice, filepath.Join(dir, voice), filepath.Join(dir, voice+".onnx")} {
+			if strings.HasSuffix(candidate, ".onnx") {
+				if _, err := os.Stat(candidate); err == nil {
+					return candidate
+				}
+			}
+		}
+		return ""
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.onnx"))
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[0]
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
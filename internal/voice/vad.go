@@ -0,0 +1,259 @@
+package voice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// VADConfig tunes hands-free turn taking.
+type VADConfig struct {
+	// Silence is how long the user must stop talking to end their answer.
+	Silence time.Duration
+	// MinSpeech is how much speech counts as an answer (ignores coughs, clicks).
+	MinSpeech time.Duration
+	// MaxWait gives up if no speech starts within this window.
+	MaxWait time.Duration
+}
+
+// DefaultVADConfig suits thinking-out-loud answers with short pauses.
+func DefaultVADConfig() VADConfig {
+	return VADConfig{
+		Silence:   1500 * time.Millisecond,
+		MinSpeech: 300 * time.Millisecond,
+		MaxWait:   60 * time.Second,
+	}
+}
+
+// VADEvent is reported by Detector.Feed.
+type VADEvent int
+
+const (
+	VADNone VADEvent = iota
+	VADSpeechStart
+	VADSpeechEnd
+	VADTimeout
+)
+
+// calibrationFrames is how much leading audio (~300ms) sets the noise floor.
+const calibrationFrames = 10
+
+// Detector is an energy-based voice activity detector over 16-bit PCM.
+type Detector struct {
+	cfg        VADConfig
+	frameSize  int
+	pending    []int16
+	threshold  float64
+	calib      []float64
+	frames     int // frames seen
+	voiced     int // consecutive voiced frames while waiting for speech
+	silent     int // consecutive silent frames after speech began
+	speechSeen bool
+}
+
+// NewDetector creates a detector for audio at sampleRate.
+func NewDetector(cfg VADConfig, sampleRate int) (*Detector, error) {
+	if sampleRate < minSampleRate {
+		return nil, fmt.Errorf("unsupported sample rate %d Hz", sampleRate)
+	}
+	return &Detector{cfg: cfg, frameSize: sampleRate * frameMillis / 1000}, nil
+}
+
+// Feed consumes samples and reports the most significant event they caused.
+// After VADSpeechEnd or VADTimeout the detector should be discarded.
+func (d *Detector) Feed(samples []int16) VADEvent {
+	d.pending = append(d.pending, samples...)
+	event := VADNone
+	for len(d.pending) >= d.frameSize {
+		e := d.frame(rms(d.pending[:d.frameSize]))
+		d.pending = d.pending[d.frameSize:]
+		if e != VADNone {
+			event = e
+			if e == VADSpeechEnd || e == VADTimeout {
+				return e
+			}
+		}
+	}
+	return event
+}
+
+func (d *Detector) frame(energy float64) VADEvent {
+	d.frames++
+	if d.threshold == 0 {
+		d.calib = append(d.calib, energy)
+		if len(d.calib) < calibrationFrames {
+			return VADNone
+		}
+		d.threshold = speechThreshold(d.calib)
+	}
+
+	voiced := energy >= d.threshold
+	if !d.speechSeen {
+		if voiced {
+			d.voiced++
+		} else {
+			d.voiced = 0
+		}
+		if d.voiced >= framesFor(d.cfg.MinSpeech) {
+			d.speechSeen = true
+			return VADSpeechStart
+		}
+		if d.cfg.MaxWait > 0 && d.frames >= framesFor(d.cfg.MaxWait) {
+			return VADTimeout
+		}
+		return VADNone
+	}
+
+	if voiced {
+		d.silent = 0
+		return VADNone
+	}
+	d.silent++
+	if d.silent >= framesFor(d.cfg.Silence) {
+		return VADSpeechEnd
+	}
+	return VADNone
+}
+
+func framesFor(dur time.Duration) int {
+	n := int(dur / (frameMillis * time.Millisecond))
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// ErrNoSpeech is returned when the user never started talking.
+var ErrNoSpeech = errors.New("no speech detected")
+
+// ErrListenCancelled is returned when a Listener is cancelled.
+var ErrListenCancelled = errors.New("listening cancelled")
+
+// Listener records until the user stops talking.
+type Listener struct {
+	rec      *Recording
+	cfg      VADConfig
+	cancel   chan struct{}
+	finish   chan struct{}
+	result   chan listenResult
+	stopOnce sync.Once
+}
+
+type listenResult struct {
+	path string
+	err  error
+}
+
+// Listen starts recording with stt and watches the audio for the end of an
+// utterance. Use Wait to get the finished recording.
+func Listen(stt STT, cfg VADConfig) (*Listener, error) {
+	rec, err := stt.StartRecording()
+	if err != nil {
+		return nil, err
+	}
+	l := &Listener{
+		rec:    rec,
+		cfg:    cfg,
+		cancel: make(chan struct{}),
+		finish: make(chan struct{}),
+		result: make(chan listenResult, 1),
+	}
+	go l.run()
+	return l, nil
+}
+
+// Wait blocks until the utterance ends and returns the WAV path.
+func (l *Listener) Wait() (string, error) {
+	r := <-l.result
+	return r.path, r.err
+}
+
+// Cancel stops listening and discards the audio.
+func (l *Listener) Cancel() {
+	l.stopOnce.Do(func() { close(l.cancel) })
+}
+
+// Finish ends the utterance now, keeping what was recorded.
+func (l *Listener) Finish() {
+	l.stopOnce.Do(func() { close(l.finish) })
+}
+
+func (l *Listener) run() {
+	path, err := l.watch()
+	l.rec.Stop()
+	if err != nil {
+		os.Remove(l.rec.AudioPath)
+		path = ""
+	}
+	l.result <- listenResult{path: path, err: err}
+}
+
+// watch tails the growing WAV file and feeds new samples to a Detector.
+func (l *Listener) watch() (string, error) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var f *os.File
+	defer func() {
+		if f != nil {
+			f.Close()
+		}
+	}()
+	var det *Detector
+	var channels int
+	var leftover []byte
+
+	for {
+		select {
+		case <-l.cancel:
+			return "", ErrListenCancelled
+		case <-l.finish:
+			return l.rec.AudioPath, nil
+		case <-ticker.C:
+		}
+
+		if f == nil {
+			opened, format, err := openWAVStream(l.rec.AudioPath)
+			if err != nil {
+				continue // recorder hasn't written the header yet
+			}
+			f, channels = opened, format.channels
+			if det, err = NewDetector(l.cfg, format.sampleRate); err != nil {
+				return "", err
+			}
+		}
+
+		chunk, err := io.ReadAll(f)
+		if err != nil {
+			return "", fmt.Errorf("read recording: %w", err)
+		}
+		data := append(leftover, chunk...)
+		usable := len(data) - len(data)%(2*channels)
+		leftover = append([]byte(nil), data[usable:]...)
+
+		switch det.Feed(decodePCM16(data[:usable], channels)) {
+		case VADSpeechEnd:
+			return l.rec.AudioPath, nil
+		case VADTimeout:
+			return "", ErrNoSpeech
+		}
+	}
+}
+
+// openWAVStream opens a WAV file that is still being written and positions
+// it at the start of the sample data.
+func openWAVStream(path string) (*os.File, wavFormat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, wavFormat{}, err
+	}
+	format, err := readWAVHeader(f)
+	if err != nil {
+		f.Close()
+		return nil, wavFormat{}, err
+	}
+	return f, format, nil
+}
@@ -0,0 +1,103 @@
+package voice
+
+import (
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func tone(seconds float64, loud bool) []int16 {
+	const rate = 16000
+	out := make([]int16, int(seconds*rate))
+	if loud {
+		for i := range out {
+			out[i] = int16(6000 * math.Sin(2*math.Pi*300*float64(i)/rate))
+		}
+	}
+	return out
+}
+
+func TestDetectorEndsAfterSilence(t *testing.T) {
+	cfg := VADConfig{Silence: time.Second, MinSpeech: 200 * time.Millisecond, MaxWait: 10 * time.Second}
+	d, err := NewDetector(cfg, 16000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e := d.Feed(tone(0.5, false)); e != VADNone {
+		t.Fatalf("leading silence: got event %d", e)
+	}
+	if e := d.Feed(tone(1, true)); e != VADSpeechStart {
+		t.Fatalf("speech: got event %d, want VADSpeechStart", e)
+	}
+	// A short pause mid-answer must not end the turn
+	if e := d.Feed(tone(0.5, false)); e != VADNone {
+		t.Fatalf("short pause: got event %d", e)
+	}
+	if e := d.Feed(tone(0.5, true)); e != VADNone {
+		t.Fatalf("resumed speech: got event %d", e)
+	}
+	if e := d.Feed(tone(1.2, false)); e != VADSpeechEnd {
+		t.Fatalf("trailing silence: got event %d, want VADSpeechEnd", e)
+	}
+}
+
+func TestDetectorTimesOutWithoutSpeech(t *testing.T) {
+	cfg := VADConfig{Silence: time.Second, MinSpeech: 200 * time.Millisecond, MaxWait: 2 * time.Second}
+	d, err := NewDetector(cfg, 16000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := d.Feed(tone(2.5, false)); e != VADTimeout {
+		t.Fatalf("got event %d, want VADTimeout", e)
+	}
+}
+
+// wavHeader writes a 16-bit mono header with the given rate and fmt chunk
+// size, followed by the data chunk header and no samples.
+func wavHeader(t *testing.T, rate, fmtSize uint32) string {
+	t.Helper()
+	data := make([]byte, 44)
+	copy(data[0:], "RIFFxxxxWAVEfmt ")
+	binary.LittleEndian.PutUint32(data[16:], fmtSize)
+	binary.LittleEndian.PutUint16(data[20:], 1)
+	binary.LittleEndian.PutUint16(data[22:], 1)
+	binary.LittleEndian.PutUint32(data[24:], rate)
+	binary.LittleEndian.PutUint16(data[34:], 16)
+	copy(data[36:], "data")
+	path := filepath.Join(t.TempDir(), "stream.wav")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRejectsTinySampleRate(t *testing.T) {
+	if _, err := NewDetector(DefaultVADConfig(), 8); err == nil {
+		t.Error("NewDetector accepted 8 Hz")
+	}
+	path := wavHeader(t, 8, 16)
+	if f, _, err := openWAVStream(path); err == nil {
+		f.Close()
+		t.Error("openWAVStream accepted 8 Hz")
+	}
+	if _, err := readWAV(path); err == nil {
+		t.Error("readWAV accepted 8 Hz")
+	}
+	if f, format, err := openWAVStream(wavHeader(t, 16000, 16)); err != nil || format.sampleRate != 16000 {
+		t.Errorf("openWAVStream(16 kHz) = %+v, %v", format, err)
+	} else {
+		f.Close()
+	}
+}
+
+func TestRejectsOversizedFmtChunk(t *testing.T) {
+	path := wavHeader(t, 16000, 0xFFFFFFF0)
+	if f, _, err := openWAVStream(path); err == nil {
+		f.Close()
+		t.Error("openWAVStream accepted a 4 GB fmt chunk")
+	}
+}
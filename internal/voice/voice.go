@@ -11,33 +11,103 @@ import (
 	"time"
 )
 
-// Speech rate in words per minute (default ~175, fast ~250, very fast ~350)
-const speechRate = "280"
+// Settings choose models, language and voice for the engines.
+type Settings struct {
+	DataDir      string // bonk's home; holds whisper models and piper voices
+	WhisperModel string // e.g. "tiny.en", "small"
+	ModelPath    string // whisper model file
+	Language     string // whisper language code, or "auto"
+	Voice        string // engine-specific TTS voice
+	Rate         int    // speech rate in words per minute (default ~175, fast ~250, very fast ~350)
+}
+
+// TTS speaks coach messages aloud.
+type TTS interface {
+	Name() string
+	// Speak starts speaking text asynchronously. Returns nil if there is
+	// nothing to speak or the engine failed to start.
+	Speak(text string) *SpeechProcess
+}
+
+// STT records spoken answers and turns them into text.
+type STT interface {
+	Name() string
+	StartRecording() (*Recording, error)
+	Transcribe(audioPath string) (string, error)
+}
+
+// Backend is the set of voice engines available on this machine. Either
+// engine may be nil; Reason explains what is missing.
+type Backend struct {
+	TTS    TTS
+	STT    STT
+	Reason string
+}
+
+// Enabled reports whether any voice engine is usable.
+func (b *Backend) Enabled() bool {
+	return b != nil && (b.TTS != nil || b.STT != nil)
+}
+
+// CanSpeak reports whether coach messages can be spoken.
+func (b *Backend) CanSpeak() bool {
+	return b != nil && b.TTS != nil
+}
+
+// CanRecord reports whether spoken answers can be recorded and transcribed.
+func (b *Backend) CanRecord() bool {
+	return b != nil && b.STT != nil
+}
+
+// Summary describes the active engines, e.g. "espeak-ng + arecord/whisper-cli".
+func (b *Backend) Summary() string {
+	if !b.Enabled() {
+		return "off"
+	}
+	var parts []string
+	if b.TTS != nil {
+		parts = append(parts, b.TTS.Name())
+	}
+	if b.STT != nil {
+		parts = append(parts, b.STT.Name())
+	}
+	return strings.Join(parts, " + ")
+}
 
 // SpeechProcess represents an in-progress TTS that can be stopped.
 type SpeechProcess struct {
-	cmd *exec.Cmd
+	cmd  *exec.Cmd
+	done chan struct{}
 }
 
-// Stop kills the speech process.
-func (s *SpeechProcess) Stop() {
-	if s != nil && s.cmd != nil && s.cmd.Process != nil {
-		s.cmd.Process.Kill()
+// startSpeech starts cmd and tracks when it exits.
+func startSpeech(cmd *exec.Cmd) *SpeechProcess {
+	if err := cmd.Start(); err != nil {
+		return nil
 	}
+	s := &SpeechProcess{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		cmd.Wait()
+		close(s.done)
+	}()
+	return s
 }
 
-// Speak uses macOS `say` command to speak text asynchronously.
-// Returns a SpeechProcess that can be stopped, or nil if nothing to speak.
-func Speak(text string) *SpeechProcess {
-	clean := StripMarkdown(text)
-	if clean == "" {
-		return nil
+// Done is closed when speech finishes or is stopped.
+func (s *SpeechProcess) Done() <-chan struct{} {
+	return s.done
+}
+
+// Stop kills the speech process (and any pipeline it spawned).
+func (s *SpeechProcess) Stop() {
+	if s == nil || s.cmd == nil || s.cmd.Process == nil {
+		return
 	}
-	cmd := exec.Command("say", "-r", speechRate, clean)
-	if err := cmd.Start(); err != nil {
-		return nil
+	if s.cmd.SysProcAttr != nil && s.cmd.SysProcAttr.Setpgid {
+		syscall.Kill(-s.cmd.Process.Pid, syscall.SIGKILL)
+		return
 	}
-	return &SpeechProcess{cmd: cmd}
+	s.cmd.Process.Kill()
 }
 
 // StripMarkdown removes markdown formatting for cleaner TTS output.
@@ -67,39 +137,18 @@ type Recording struct {
 	AudioPath string
 }
 
-// StartRecording begins recording audio using sox.
-// Returns a Recording that can be stopped later.
-func StartRecording() (*Recording, error) {
-	path := filepath.Join(os.TempDir(), fmt.Sprintf("bonk-%d.wav", time.Now().UnixNano()))
-	// sox -d: default input device, -q: quiet, -r 16000: sample rate, -c 1: mono
-	cmd := exec.Command("sox", "-d", "-q", "-r", "16000", "-c", "1", path)
-	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start recording: %w", err)
-	}
-	return &Recording{cmd: cmd, AudioPath: path}, nil
-}
-
 // Stop ends the recording and returns the audio file path.
 func (r *Recording) Stop() (string, error) {
 	if r.cmd == nil || r.cmd.Process == nil {
 		return "", fmt.Errorf("no recording in progress")
 	}
-	// Send SIGINT to gracefully stop sox
+	// Send SIGINT so sox/arecord finalize the WAV header
 	r.cmd.Process.Signal(syscall.SIGINT)
 	r.cmd.Wait()
 	return r.AudioPath, nil
 }
 
-// Transcribe runs whisper.cpp on an audio file and returns the transcription.
-func Transcribe(audioPath string) (string, error) {
-	modelPath := filepath.Join(os.Getenv("HOME"), ".bonk", "ggml-tiny.en.bin")
-	if _, err := os.Stat(modelPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("whisper model not found at %s - run: curl -L https://huggingface.co/ggerganov/whisper.cpp/resolve/main/ggml-tiny.en.bin -o %s", modelPath, modelPath)
-	}
-	cmd := exec.Command("whisper-cli", "-m", modelPath, "-f", audioPath, "--no-timestamps")
-	out, err := cmd.Output()
-	if err != nil {
-		return "", fmt.Errorf("whisper transcription failed: %w", err)
-	}
-	return strings.TrimSpace(string(out)), nil
+// tempAudioPath returns a fresh path for a 16kHz mono WAV recording.
+func tempAudioPath() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("bonk-%d.wav", time.Now().UnixNano()))
 }
@@ -0,0 +1,169 @@
+package voice
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// frameMillis is the analysis window used for energy measurements.
+const frameMillis = 30
+
+// maxFmtChunk bounds the fmt chunk read into memory; a real one is 16 to
+// 40 bytes, so anything much larger is a corrupt header.
+const maxFmtChunk = 1024
+
+// wavAudio is decoded 16-bit PCM audio (mixed down to mono).
+type wavAudio struct {
+	sampleRate int
+	samples    []int16
+}
+
+func (a *wavAudio) duration() float64 {
+	if a.sampleRate == 0 {
+		return 0
+	}
+	return float64(len(a.samples)) / float64(a.sampleRate)
+}
+
+// minSampleRate is the lowest sample rate accepted; speech is never
+// recorded below telephone quality, and it keeps an analysis frame from
+// being empty.
+const minSampleRate = 8000
+
+// wavFormat is what the fmt chunk says about the samples.
+type wavFormat struct {
+	sampleRate int
+	channels   int
+}
+
+// readWAVHeader reads a 16-bit PCM WAV header from r up to the data chunk,
+// leaving r at the first sample. The data chunk's length is not trusted:
+// it is zero while a recorder is still writing and often wrong after one
+// is interrupted.
+func readWAVHeader(r io.ReadSeeker) (wavFormat, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return wavFormat{}, fmt.Errorf("read wav header: %w", err)
+	}
+	if string(riff[0:4]) != "RIFF" || string(riff[8:12]) != "WAVE" {
+		return wavFormat{}, fmt.Errorf("not a WAV file")
+	}
+
+	var format wavFormat
+	var bitsPerSample int
+	for {
+		var hdr [8]byte
+		if _, err := io.ReadFull(r, hdr[:]); err != nil {
+			return wavFormat{}, fmt.Errorf("no data chunk: %w", err)
+		}
+		size := int64(binary.LittleEndian.Uint32(hdr[4:8]))
+
+		switch string(hdr[0:4]) {
+		case "fmt ":
+			if size > maxFmtChunk {
+				return wavFormat{}, fmt.Errorf("fmt chunk of %d bytes", size)
+			}
+			buf := make([]byte, size)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return wavFormat{}, fmt.Errorf("read fmt chunk: %w", err)
+			}
+			if len(buf) < 16 {
+				return wavFormat{}, fmt.Errorf("short fmt chunk")
+			}
+			format.channels = int(binary.LittleEndian.Uint16(buf[2:4]))
+			format.sampleRate = int(binary.LittleEndian.Uint32(buf[4:8]))
+			bitsPerSample = int(binary.LittleEndian.Uint16(buf[14:16]))
+		case "data":
+			if bitsPerSample != 16 || format.channels < 1 {
+				return wavFormat{}, fmt.Errorf("unsupported WAV format (%d-bit, %d channels)", bitsPerSample, format.channels)
+			}
+			if format.sampleRate < minSampleRate {
+				return wavFormat{}, fmt.Errorf("unsupported sample rate %d Hz", format.sampleRate)
+			}
+			return format, nil
+		default:
+			if _, err := r.Seek(size+size%2, io.SeekCurrent); err != nil {
+				return wavFormat{}, err
+			}
+		}
+	}
+}
+
+// readWAV decodes a 16-bit PCM WAV file as written by sox/arecord. Recorders
+// interrupted with SIGINT sometimes leave a zero or oversized data length in
+// the header, so the data chunk is read to EOF.
+func readWAV(path string) (*wavAudio, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format, err := readWAVHeader(f)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("read data chunk: %w", err)
+	}
+	return &wavAudio{sampleRate: format.sampleRate, samples: decodePCM16(data, format.channels)}, nil
+}
+
+// decodePCM16 converts little-endian interleaved samples to mono.
+func decodePCM16(data []byte, channels int) []int16 {
+	frameBytes := 2 * channels
+	n := len(data) / frameBytes
+	out := make([]int16, n)
+	for i := 0; i < n; i++ {
+		sum := 0
+		for c := 0; c < channels; c++ {
+			off := i*frameBytes + 2*c
+			sum += int(int16(binary.LittleEndian.Uint16(data[off : off+2])))
+		}
+		out[i] = int16(sum / channels)
+	}
+	return out
+}
+
+// frameEnergies returns the RMS energy of consecutive frameMillis windows.
+func frameEnergies(samples []int16, sampleRate int) []float64 {
+	size := sampleRate * frameMillis / 1000
+	if size <= 0 {
+		return nil
+	}
+	energies := make([]float64, 0, len(samples)/size)
+	for start := 0; start+size <= len(samples); start += size {
+		energies = append(energies, rms(samples[start:start+size]))
+	}
+	return energies
+}
+
+func rms(samples []int16) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range samples {
+		v := float64(s)
+		sum += v * v
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+// speechThreshold picks an energy level separating speech from background
+// noise: a multiple of the quietest decile, with an absolute floor.
+func speechThreshold(energies []float64) float64 {
+	const minThreshold = 300
+	if len(energies) == 0 {
+		return minThreshold
+	}
+	sorted := append([]float64(nil), energies...)
+	sort.Float64s(sorted)
+	noiseFloor := sorted[len(sorted)/10]
+	return math.Max(minThreshold, noiseFloor*3)
+}